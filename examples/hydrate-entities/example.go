@@ -122,8 +122,8 @@ func main() {
 	}
 
 	stp := domain.StartParams{
-		Source:   "api",
-		SyncMode: "CREATE_OR_UPDATE",
+		Source:   domain.SyncSourceAPI,
+		SyncMode: domain.SyncModeCreateOrUpdate,
 	}
 	syp := domain.SyncPayload{
 		Entities: uploadPayloads,
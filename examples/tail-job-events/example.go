@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"time"
+
+	j1 "github.com/jupiterone/jupiterone-client-go/jupiterone"
+)
+
+func getEnvWithDefault(key string, defaultVal string) string {
+	value, exists := os.LookupEnv(key)
+	if !exists {
+		value = defaultVal
+	}
+	return value
+}
+
+func main() {
+	// Set configuration
+	config := j1.Config{
+		APIKey:    getEnvWithDefault("J1_API_TOKEN", ""),
+		AccountID: getEnvWithDefault("J1_ACCOUNT", ""),
+		Region:    getEnvWithDefault("J1_REGION", "us"),
+	}
+
+	instanceID := getEnvWithDefault("J1_INTEGRATION_INSTANCE_ID", "")
+	jobID := getEnvWithDefault("J1_INTEGRATION_JOB_ID", "")
+	if instanceID == "" || jobID == "" {
+		log.Fatal("J1_INTEGRATION_INSTANCE_ID and J1_INTEGRATION_JOB_ID must be set")
+	}
+
+	client, err := j1.NewClient(&config)
+	if err != nil {
+		log.Fatalf("failed to create client: %v", err)
+	}
+
+	// Stop tailing on Ctrl+C.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	events, errc := client.Integration.TailJobEvents(ctx, instanceID, jobID)
+
+	for event := range events {
+		createdAt := time.UnixMilli(int64(event.CreateDate)).Format(time.RFC3339)
+		fmt.Printf("%s  %-20s %s\n", createdAt, event.Name, event.Description)
+	}
+
+	if err := <-errc; err != nil {
+		log.Fatalf("stopped tailing job events: %v", err)
+	}
+
+	log.Print("job finished.")
+}
@@ -3,6 +3,7 @@ package jupiterone
 import (
 	"context"
 	"encoding/json"
+	"time"
 )
 
 // AuditService provides the Audit API functions.
@@ -21,13 +22,58 @@ type ListAuditEventsResponse struct {
 
 // Audit Event represents a single Audit event in a JupiterOne account.
 type AuditEvent struct {
-	ID                string
-	ResourceType      string
-	ResourceID        string
-	Category          string
-	Timestamp         uint
-	PerformedByUserID string
-	Data              json.RawMessage
+	ID                string            `json:"id"`
+	ResourceType      AuditResourceType `json:"resourceType"`
+	ResourceID        string            `json:"resourceId"`
+	Category          AuditCategory     `json:"category"`
+	Timestamp         uint              `json:"timestamp"`
+	PerformedByUserID string            `json:"performedByUserId"`
+	Data              json.RawMessage   `json:"data"`
+}
+
+// Time returns Timestamp, which is in milliseconds since the Unix epoch, as a time.Time.
+func (e *AuditEvent) Time() time.Time {
+	return time.UnixMilli(int64(e.Timestamp))
+}
+
+// DecodeData decodes Data with the decoder registered for Category.
+// See DecodeAuditData.
+func (e *AuditEvent) DecodeData() (interface{}, error) {
+	return DecodeAuditData(e.Category, e.Data)
+}
+
+// AuditEventFilter narrows FilteredAuditEventsIterator. Zero-valued fields are not applied.
+//
+// The API has no filter arguments, so the filters are applied by the client to each page.
+type AuditEventFilter struct {
+	// Since stops the iteration at the first event older than Since. Events are
+	// returned newest first, so no further pages are requested after that.
+	Since         time.Time
+	Categories    []AuditCategory
+	ResourceTypes []AuditResourceType
+	// UserIDs matches AuditEvent.PerformedByUserID.
+	UserIDs []string
+	// Limit is the page size; 0 uses the default API behavior.
+	Limit int
+}
+
+func (f *AuditEventFilter) matches(event *AuditEvent) bool {
+	return matchesAny(f.Categories, event.Category) &&
+		matchesAny(f.ResourceTypes, event.ResourceType) &&
+		matchesAny(f.UserIDs, event.PerformedByUserID)
+}
+
+// matchesAny reports whether value is one of values. An empty values matches everything.
+func matchesAny[T comparable](values []T, value T) bool {
+	if len(values) == 0 {
+		return true
+	}
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
 }
 
 // ListAuditEvents lists the audit events in the JupiterOne account.
@@ -36,10 +82,59 @@ type AuditEvent struct {
 // A limit of 0 uses the default behavior of the API.
 //
 // The first call should use an empty string for cursor.
-// To paginate through all items, first check if PageInfo.HasNextPage
-// is true. If it is, call ListAuditEvents again and pass the
-// PageInfo.Cursor as the cursor parameter.
+// To paginate, the caller should check PageInfo.HasNextPage and, if true,
+// pass PageInfo.Cursor as the cursor on the next call. AuditEventsIterator
+// does this for the caller.
 func (as *AuditService) ListAuditEvents(limit int, cursor string) (*ListAuditEventsResponse, error) {
+	return as.listAuditEvents(context.Background(), limit, cursor)
+}
+
+// AuditEventsIterator returns an Iterator over every audit event in the account.
+// limit is the page size; pass 0 for the default API behavior.
+func (as *AuditService) AuditEventsIterator(ctx context.Context, limit int) *Iterator[*AuditEvent] {
+	return NewIterator(ctx, func(ctx context.Context, cursor string) (Page[*AuditEvent], error) {
+		resp, err := as.listAuditEvents(ctx, limit, cursor)
+		if err != nil {
+			return Page[*AuditEvent]{}, err
+		}
+
+		return Page[*AuditEvent]{
+			Items:      resp.Items,
+			NextCursor: resp.PageInfo.Cursor,
+			HasNext:    resp.PageInfo.HasNextPage,
+		}, nil
+	})
+}
+
+// FilteredAuditEventsIterator returns an Iterator over the audit events in the account
+// that match filter, newest first.
+func (as *AuditService) FilteredAuditEventsIterator(ctx context.Context, filter AuditEventFilter) *Iterator[*AuditEvent] {
+	return NewIterator(ctx, func(ctx context.Context, cursor string) (Page[*AuditEvent], error) {
+		resp, err := as.listAuditEvents(ctx, filter.Limit, cursor)
+		if err != nil {
+			return Page[*AuditEvent]{}, err
+		}
+
+		page := Page[*AuditEvent]{
+			NextCursor: resp.PageInfo.Cursor,
+			HasNext:    resp.PageInfo.HasNextPage,
+		}
+
+		for _, event := range resp.Items {
+			if !filter.Since.IsZero() && event.Time().Before(filter.Since) {
+				page.HasNext = false
+				break
+			}
+			if filter.matches(event) {
+				page.Items = append(page.Items, event)
+			}
+		}
+
+		return page, nil
+	})
+}
+
+func (as *AuditService) listAuditEvents(ctx context.Context, limit int, cursor string) (*ListAuditEventsResponse, error) {
 	req := as.client.prepareRequest(`
     query GetAuditEventsForAccount($limit: Int, $cursor: String) {
       getAuditEventsForAccount(limit: $limit, cursor: $cursor) {
@@ -73,7 +168,7 @@ func (as *AuditService) ListAuditEvents(limit int, cursor string) (*ListAuditEve
 		GetAuditEventsForAccount: &ListAuditEventsResponse{},
 	}
 
-	err := as.client.graphqlClient.Run(context.Background(), req, &resp)
+	err := as.client.graphqlClient.Run(ctx, req, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,79 @@
+package jupiterone
+
+import (
+	"encoding/json"
+	"sync"
+)
+
+// AuditCategory is the category of an audit event, such as RULE_UPDATED.
+type AuditCategory string
+
+// Known audit event categories. The API may report categories not listed here.
+const (
+	AuditCategoryRuleCreated                      AuditCategory = "RULE_CREATED"
+	AuditCategoryRuleUpdated                      AuditCategory = "RULE_UPDATED"
+	AuditCategoryRuleDeleted                      AuditCategory = "RULE_DELETED"
+	AuditCategoryQuestionCreated                  AuditCategory = "QUESTION_CREATED"
+	AuditCategoryQuestionUpdated                  AuditCategory = "QUESTION_UPDATED"
+	AuditCategoryQuestionDeleted                  AuditCategory = "QUESTION_DELETED"
+	AuditCategoryIntegrationInstanceCreated       AuditCategory = "INTEGRATION_INSTANCE_CREATED"
+	AuditCategoryIntegrationInstanceConfigUpdated AuditCategory = "INTEGRATION_INSTANCE_CONFIG_UPDATED"
+	AuditCategoryIntegrationInstanceDeleted       AuditCategory = "INTEGRATION_INSTANCE_DELETED"
+)
+
+// AuditResourceType is the type of resource an audit event refers to.
+type AuditResourceType string
+
+// Known audit resource types. The API may report resource types not listed here.
+const (
+	AuditResourceTypeRule                AuditResourceType = "RULE"
+	AuditResourceTypeQuestion            AuditResourceType = "QUESTION"
+	AuditResourceTypeIntegrationInstance AuditResourceType = "INTEGRATION_INSTANCE"
+)
+
+// AuditDataDecoder decodes the Data of an audit event into a concrete type.
+type AuditDataDecoder func(data json.RawMessage) (interface{}, error)
+
+var (
+	auditDataDecodersMu sync.RWMutex
+	auditDataDecoders   = map[AuditCategory]AuditDataDecoder{
+		AuditCategoryRuleUpdated:                      DecodeAuditDataAs[RuleUpdatedData](),
+		AuditCategoryQuestionUpdated:                  DecodeAuditDataAs[QuestionUpdatedData](),
+		AuditCategoryIntegrationInstanceConfigUpdated: DecodeAuditDataAs[IntegrationConfigChangedData](),
+	}
+)
+
+// RegisterAuditDataDecoder sets the decoder used by DecodeAuditData for category,
+// replacing any decoder already registered for it. It is safe for concurrent use.
+func RegisterAuditDataDecoder(category AuditCategory, decoder AuditDataDecoder) {
+	auditDataDecodersMu.Lock()
+	defer auditDataDecodersMu.Unlock()
+
+	auditDataDecoders[category] = decoder
+}
+
+// DecodeAuditDataAs returns an AuditDataDecoder that unmarshals Data into a *T.
+func DecodeAuditDataAs[T any]() AuditDataDecoder {
+	return func(data json.RawMessage) (interface{}, error) {
+		var v T
+		if err := json.Unmarshal(data, &v); err != nil {
+			return nil, err
+		}
+		return &v, nil
+	}
+}
+
+// DecodeAuditData decodes data with the decoder registered for category. By default
+// RULE_UPDATED decodes to *RuleUpdatedData, QUESTION_UPDATED to *QuestionUpdatedData and
+// INTEGRATION_INSTANCE_CONFIG_UPDATED to *IntegrationConfigChangedData. Data of categories
+// without a decoder is returned unchanged as json.RawMessage.
+func DecodeAuditData(category AuditCategory, data json.RawMessage) (interface{}, error) {
+	auditDataDecodersMu.RLock()
+	decoder, ok := auditDataDecoders[category]
+	auditDataDecodersMu.RUnlock()
+
+	if !ok {
+		return data, nil
+	}
+	return decoder(data)
+}
@@ -0,0 +1,288 @@
+package jupiterone
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// AuditEventSink receives the events written by ExportAuditEvents.
+type AuditEventSink interface {
+	Write(event *AuditEvent) error
+	// Flush is called once after the last event has been written.
+	Flush() error
+}
+
+// JSONLSink writes each audit event as a line of JSON.
+type JSONLSink struct {
+	encoder *json.Encoder
+}
+
+// NewJSONLSink returns a JSONLSink that writes to w.
+func NewJSONLSink(w io.Writer) *JSONLSink {
+	return &JSONLSink{encoder: json.NewEncoder(w)}
+}
+
+func (s *JSONLSink) Write(event *AuditEvent) error {
+	return s.encoder.Encode(event)
+}
+
+func (s *JSONLSink) Flush() error {
+	return nil
+}
+
+// CSVSink writes audit events as CSV, one row per event as it is written. The fields of
+// each event's Data are flattened into "data."-prefixed columns, with nested objects
+// joined by dots and arrays encoded as JSON.
+//
+// Because rows are not buffered, the data columns are fixed before the first row: they
+// are the keys passed to NewCSVSink or, if none were, the flattened keys of the first
+// event. Flattened fields of later events without a column are written to a final
+// "otherData" column as a JSON object keyed by the flattened key.
+type CSVSink struct {
+	w           *csv.Writer
+	dataKeys    []string
+	dataColumns map[string]bool
+}
+
+var auditCSVColumns = []string{"id", "resourceType", "resourceId", "category", "timestamp", "performedByUserId"}
+
+// NewCSVSink returns a CSVSink that writes to w. dataKeys are the flattened keys of Data
+// to write as columns, such as "rule.name" for the column "data.rule.name".
+func NewCSVSink(w io.Writer, dataKeys ...string) *CSVSink {
+	return &CSVSink{w: csv.NewWriter(w), dataKeys: dataKeys}
+}
+
+func (s *CSVSink) Write(event *AuditEvent) error {
+	data := map[string]string{}
+	if len(event.Data) > 0 {
+		var v interface{}
+		if err := json.Unmarshal(event.Data, &v); err != nil {
+			return fmt.Errorf("audit event %s: %w", event.ID, err)
+		}
+		flattenAuditData("", v, data)
+	}
+
+	if s.dataColumns == nil {
+		if len(s.dataKeys) == 0 {
+			for key := range data {
+				s.dataKeys = append(s.dataKeys, key)
+			}
+			sort.Strings(s.dataKeys)
+		}
+		if err := s.writeHeader(); err != nil {
+			return err
+		}
+	}
+
+	record := []string{
+		event.ID,
+		string(event.ResourceType),
+		event.ResourceID,
+		string(event.Category),
+		event.Time().UTC().Format(time.RFC3339Nano),
+		event.PerformedByUserID,
+	}
+	for _, key := range s.dataKeys {
+		record = append(record, data[key])
+	}
+
+	other := map[string]string{}
+	for key, value := range data {
+		if !s.dataColumns[key] {
+			other[key] = value
+		}
+	}
+	otherData := ""
+	if len(other) > 0 {
+		b, err := json.Marshal(other)
+		if err != nil {
+			return err
+		}
+		otherData = string(b)
+	}
+	record = append(record, otherData)
+
+	if err := s.w.Write(record); err != nil {
+		return err
+	}
+
+	// Pass the row on to the underlying writer now rather than holding it in memory.
+	s.w.Flush()
+	return s.w.Error()
+}
+
+// Flush writes the header if no event was written, so that an empty export is still valid CSV.
+func (s *CSVSink) Flush() error {
+	if s.dataColumns == nil {
+		if err := s.writeHeader(); err != nil {
+			return err
+		}
+	}
+
+	s.w.Flush()
+	return s.w.Error()
+}
+
+func (s *CSVSink) writeHeader() error {
+	s.dataColumns = map[string]bool{}
+	columns := append([]string{}, auditCSVColumns...)
+	for _, key := range s.dataKeys {
+		s.dataColumns[key] = true
+		columns = append(columns, "data."+key)
+	}
+	columns = append(columns, "otherData")
+
+	return s.w.Write(columns)
+}
+
+// flattenAuditData adds the leaves of value to row, keyed by their dot-joined path below prefix.
+func flattenAuditData(prefix string, value interface{}, row map[string]string) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, nested := range v {
+			if prefix != "" {
+				key = prefix + "." + key
+			}
+			flattenAuditData(key, nested, row)
+		}
+	case nil:
+		row[prefix] = ""
+	case string:
+		row[prefix] = v
+	case float64:
+		row[prefix] = strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		row[prefix] = strconv.FormatBool(v)
+	default:
+		b, _ := json.Marshal(v)
+		row[prefix] = string(b)
+	}
+}
+
+// AuditExportCheckpoint is the high-water mark of an audit export: the timestamp of the
+// newest exported event and the ids of the exported events with that timestamp.
+type AuditExportCheckpoint struct {
+	Timestamp uint     `json:"timestamp"`
+	EventIDs  []string `json:"eventIds"`
+}
+
+// AuditCheckpointStore persists the high-water mark of ExportAuditEvents between runs.
+// Load should return a nil checkpoint and a nil error when nothing has been saved.
+type AuditCheckpointStore interface {
+	Load() (*AuditExportCheckpoint, error)
+	Save(checkpoint *AuditExportCheckpoint) error
+}
+
+// FileAuditCheckpointStore is an AuditCheckpointStore that keeps the checkpoint
+// as JSON in a single file on disk.
+type FileAuditCheckpointStore struct {
+	Path string
+}
+
+// NewFileAuditCheckpointStore returns a FileAuditCheckpointStore that writes to path.
+func NewFileAuditCheckpointStore(path string) *FileAuditCheckpointStore {
+	return &FileAuditCheckpointStore{Path: path}
+}
+
+// Load reads the checkpoint from disk. A missing file is not an error.
+func (f *FileAuditCheckpointStore) Load() (*AuditExportCheckpoint, error) {
+	b, err := os.ReadFile(f.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var checkpoint AuditExportCheckpoint
+	if err := json.Unmarshal(b, &checkpoint); err != nil {
+		return nil, err
+	}
+
+	return &checkpoint, nil
+}
+
+// Save atomically replaces the checkpoint file.
+func (f *FileAuditCheckpointStore) Save(checkpoint *AuditExportCheckpoint) error {
+	b, err := json.Marshal(checkpoint)
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(f.Path, b)
+}
+
+// ExportAuditEvents writes the account's audit events to sink, newest first, and returns
+// how many were written.
+//
+// If store is not nil, only events newer than the stored checkpoint are exported. The
+// checkpoint is only advanced once every event has been written and sink has been
+// flushed. An export that fails part way does not move the checkpoint, so the next run
+// exports the same events again and sinks may see some events twice.
+func (as *AuditService) ExportAuditEvents(ctx context.Context, sink AuditEventSink, store AuditCheckpointStore) (int, error) {
+	var checkpoint *AuditExportCheckpoint
+	if store != nil {
+		var err error
+		if checkpoint, err = store.Load(); err != nil {
+			return 0, err
+		}
+	}
+
+	filter := AuditEventFilter{}
+	exported := map[string]bool{}
+	if checkpoint != nil {
+		filter.Since = time.UnixMilli(int64(checkpoint.Timestamp))
+		for _, id := range checkpoint.EventIDs {
+			exported[id] = true
+		}
+	}
+
+	var next *AuditExportCheckpoint
+	count := 0
+
+	it := as.FilteredAuditEventsIterator(ctx, filter)
+	for it.Next() {
+		event := it.Item()
+		if checkpoint != nil && event.Timestamp == checkpoint.Timestamp && exported[event.ID] {
+			continue
+		}
+
+		if err := sink.Write(event); err != nil {
+			return count, err
+		}
+		count++
+
+		switch {
+		case next == nil || event.Timestamp > next.Timestamp:
+			next = &AuditExportCheckpoint{Timestamp: event.Timestamp, EventIDs: []string{event.ID}}
+		case event.Timestamp == next.Timestamp:
+			next.EventIDs = append(next.EventIDs, event.ID)
+		}
+	}
+	if err := it.Err(); err != nil {
+		return count, err
+	}
+
+	if err := sink.Flush(); err != nil {
+		return count, err
+	}
+
+	if store == nil || next == nil {
+		return count, nil
+	}
+
+	// Events that share the previous high-water timestamp were exported by an earlier run.
+	if checkpoint != nil && next.Timestamp == checkpoint.Timestamp {
+		next.EventIDs = append(next.EventIDs, checkpoint.EventIDs...)
+	}
+
+	return count, store.Save(next)
+}
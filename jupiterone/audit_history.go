@@ -0,0 +1,135 @@
+package jupiterone
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/jupiterone/jupiterone-client-go/jupiterone/domain"
+)
+
+// ResourceHistoryItem is a single change in the history of a resource.
+type ResourceHistoryItem struct {
+	Timestamp         uint            `json:"timestamp"`
+	PerformedByUserID string          `json:"performedByUserId"`
+	Data              json.RawMessage `json:"data"`
+
+	// Category is the category the history was requested for.
+	Category AuditCategory `json:"-"`
+}
+
+// Time returns Timestamp, which is in milliseconds since the Unix epoch, as a time.Time.
+func (i *ResourceHistoryItem) Time() time.Time {
+	return time.UnixMilli(int64(i.Timestamp))
+}
+
+// ResourceUpdate is the Data of an audit event that records an update to a resource:
+// the resource before and after the change.
+type ResourceUpdate[T any] struct {
+	Before T `json:"before"`
+	After  T `json:"after"`
+}
+
+// Changes lists the top-level properties that differ between Before and After.
+func (u *ResourceUpdate[T]) Changes() ([]domain.PropertyChange, error) {
+	before, err := toPropertyMap(u.Before)
+	if err != nil {
+		return nil, err
+	}
+
+	after, err := toPropertyMap(u.After)
+	if err != nil {
+		return nil, err
+	}
+
+	return diffProperties(before, after), nil
+}
+
+// QuestionUpdatedData is the Data of a QUESTION_UPDATED event.
+type QuestionUpdatedData = ResourceUpdate[*Question]
+
+// RuleUpdatedData is the Data of a RULE_UPDATED event. Rules are kept as maps because
+// the shape of their operations depends on the rule's spec version.
+type RuleUpdatedData = ResourceUpdate[map[string]interface{}]
+
+// IntegrationConfigChangedData is the Data of an INTEGRATION_INSTANCE_CONFIG_UPDATED event:
+// the instance config before and after the change.
+type IntegrationConfigChangedData = ResourceUpdate[map[string]interface{}]
+
+// DecodeData decodes Data with the decoder registered for Category.
+// See DecodeAuditData.
+func (i *ResourceHistoryItem) DecodeData() (interface{}, error) {
+	return DecodeAuditData(i.Category, i.Data)
+}
+
+// ResourceHistory returns an Iterator over the changes in category made to the resource
+// with the given type and id. limit is the page size; pass 0 for the default API behavior.
+func (as *AuditService) ResourceHistory(ctx context.Context, resourceType AuditResourceType, resourceID string, category AuditCategory, limit int) *Iterator[*ResourceHistoryItem] {
+	return NewIterator(ctx, func(ctx context.Context, cursor string) (Page[*ResourceHistoryItem], error) {
+		req := as.client.prepareRequest(`
+    query GetHistoryForResource($resourceType: String!, $resourceId: String, $category: String!, $limit: Int, $cursor: String) {
+      getHistoryForResource(resourceType: $resourceType, resourceId: $resourceId, category: $category, limit: $limit, cursor: $cursor) {
+        items {
+          timestamp
+          performedByUserId
+          data
+        }
+        pageInfo {
+          endCursor
+          hasNextPage
+        }
+      }
+    }`)
+
+		req.Var("resourceType", resourceType)
+		req.Var("resourceId", resourceID)
+		req.Var("category", category)
+
+		if limit != 0 {
+			req.Var("limit", limit)
+		}
+
+		if cursor != "" {
+			req.Var("cursor", cursor)
+		}
+
+		var resp struct {
+			GetHistoryForResource struct {
+				Items    []*ResourceHistoryItem `json:"items"`
+				PageInfo struct {
+					HasNextPage bool   `json:"hasNextPage"`
+					Cursor      string `json:"endCursor"`
+				} `json:"pageInfo"`
+			} `json:"getHistoryForResource"`
+		}
+
+		if err := as.client.graphqlClient.Run(ctx, req, &resp); err != nil {
+			return Page[*ResourceHistoryItem]{}, err
+		}
+
+		result := resp.GetHistoryForResource
+		for _, item := range result.Items {
+			item.Category = category
+		}
+
+		return Page[*ResourceHistoryItem]{
+			Items:      result.Items,
+			NextCursor: result.PageInfo.Cursor,
+			HasNext:    result.PageInfo.HasNextPage,
+		}, nil
+	})
+}
+
+// toPropertyMap normalizes a value through JSON into a property map.
+func toPropertyMap(v interface{}) (map[string]interface{}, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var properties map[string]interface{}
+	if err := json.Unmarshal(b, &properties); err != nil {
+		return nil, err
+	}
+	return properties, nil
+}
@@ -0,0 +1,316 @@
+package jupiterone
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jupiterone/jupiterone-client-go/jupiterone/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilteredAuditEventsIterator(t *testing.T) {
+	now := time.Now()
+	at := func(ago time.Duration) int64 { return now.Add(-ago).UnixMilli() }
+	pages := 0
+
+	client := newGraphQLTestClient(t, graphQLHandler{
+		"GetAuditEventsForAccount": func(vars map[string]interface{}) interface{} {
+			pages++
+			if vars["cursor"] == nil {
+				return map[string]interface{}{
+					"getAuditEventsForAccount": map[string]interface{}{
+						"items": []interface{}{
+							map[string]interface{}{"id": "1", "category": "RULE_UPDATED", "performedByUserId": "alice", "timestamp": at(time.Hour)},
+							map[string]interface{}{"id": "2", "category": "QUESTION_UPDATED", "performedByUserId": "alice", "timestamp": at(2 * time.Hour)},
+						},
+						"pageInfo": map[string]interface{}{"endCursor": "next", "hasNextPage": true},
+					},
+				}
+			}
+			return map[string]interface{}{
+				"getAuditEventsForAccount": map[string]interface{}{
+					"items": []interface{}{
+						map[string]interface{}{"id": "3", "category": "RULE_UPDATED", "performedByUserId": "bob", "timestamp": at(3 * time.Hour)},
+						map[string]interface{}{"id": "4", "category": "RULE_UPDATED", "performedByUserId": "alice", "timestamp": at(5 * time.Hour)},
+					},
+					"pageInfo": map[string]interface{}{"endCursor": "last", "hasNextPage": true},
+				},
+			}
+		},
+	})
+
+	events, err := client.Audit.FilteredAuditEventsIterator(context.Background(), AuditEventFilter{
+		Since:      now.Add(-4 * time.Hour),
+		Categories: []AuditCategory{AuditCategoryRuleUpdated},
+	}).All()
+	assert.NoError(t, err)
+
+	ids := []string{}
+	for _, event := range events {
+		ids = append(ids, event.ID)
+	}
+	assert.Equal(t, []string{"1", "3"}, ids)
+	assert.Equal(t, 2, pages, "no page should be requested after an event older than Since")
+	assert.Equal(t, at(time.Hour), events[0].Time().UnixMilli())
+}
+
+func TestFilteredAuditEventsIteratorStopsAtSince(t *testing.T) {
+	// Three pages of two events each, newest first, one second apart: 6000 ms down to 1000 ms.
+	newAuditClient := func(requested *[]string) *Client {
+		return newGraphQLTestClient(t, graphQLHandler{
+			"GetAuditEventsForAccount": func(vars map[string]interface{}) interface{} {
+				page := 0
+				if cursor, ok := vars["cursor"].(string); ok {
+					page, _ = strconv.Atoi(cursor)
+				}
+				*requested = append(*requested, strconv.Itoa(page))
+
+				items := []interface{}{}
+				for i := 0; i < 2; i++ {
+					n := page*2 + i
+					items = append(items, map[string]interface{}{"id": strconv.Itoa(n), "timestamp": (6 - n) * 1000})
+				}
+
+				return map[string]interface{}{
+					"getAuditEventsForAccount": map[string]interface{}{
+						"items":    items,
+						"pageInfo": map[string]interface{}{"endCursor": strconv.Itoa(page + 1), "hasNextPage": page < 2},
+					},
+				}
+			},
+		})
+	}
+
+	tests := []struct {
+		title     string
+		since     time.Time
+		events    int
+		requested []string
+	}{
+		{"cutoff inside the first page", time.UnixMilli(5500), 1, []string{"0"}},
+		{"cutoff at the first event of the second page", time.UnixMilli(4000), 3, []string{"0", "1"}},
+		{"cutoff at the last event of the first page", time.UnixMilli(5000), 2, []string{"0", "1"}},
+		{"no cutoff", time.Time{}, 6, []string{"0", "1", "2"}},
+	}
+
+	for _, tt := range tests {
+		var requested []string
+		client := newAuditClient(&requested)
+
+		events, err := client.Audit.FilteredAuditEventsIterator(context.Background(), AuditEventFilter{Since: tt.since}).All()
+		assert.NoError(t, err, tt.title)
+		assert.Len(t, events, tt.events, tt.title)
+		assert.Equal(t, tt.requested, requested, tt.title)
+	}
+}
+
+func TestExportAuditEvents(t *testing.T) {
+	items := []interface{}{
+		map[string]interface{}{"id": "3", "category": "RULE_UPDATED", "timestamp": 3000, "data": map[string]interface{}{"rule": map[string]interface{}{"name": "r"}}},
+		map[string]interface{}{"id": "2", "category": "QUESTION_CREATED", "timestamp": 2000, "data": map[string]interface{}{"tags": []interface{}{"a"}}},
+		map[string]interface{}{"id": "1", "category": "RULE_CREATED", "timestamp": 2000, "data": map[string]interface{}{}},
+	}
+
+	client := newGraphQLTestClient(t, graphQLHandler{
+		"GetAuditEventsForAccount": func(vars map[string]interface{}) interface{} {
+			return map[string]interface{}{
+				"getAuditEventsForAccount": map[string]interface{}{
+					"items":    items,
+					"pageInfo": map[string]interface{}{"hasNextPage": false},
+				},
+			}
+		},
+	})
+
+	store := NewFileAuditCheckpointStore(filepath.Join(t.TempDir(), "audit.json"))
+
+	var csvOut bytes.Buffer
+	n, err := client.Audit.ExportAuditEvents(context.Background(), NewCSVSink(&csvOut), store)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, n)
+
+	lines := strings.Split(strings.TrimSpace(csvOut.String()), "\n")
+	// The data columns are taken from the first event; fields of later events without
+	// a column are kept in otherData.
+	assert.Equal(t, "id,resourceType,resourceId,category,timestamp,performedByUserId,data.rule.name,otherData", lines[0])
+	assert.Equal(t, `3,,,RULE_UPDATED,1970-01-01T00:00:03Z,,r,`, lines[1])
+	assert.Equal(t, `2,,,QUESTION_CREATED,1970-01-01T00:00:02Z,,,"{""tags"":""[\""a\""]""}"`, lines[2])
+	assert.Equal(t, `1,,,RULE_CREATED,1970-01-01T00:00:02Z,,,`, lines[3])
+
+	checkpoint, err := store.Load()
+	assert.NoError(t, err)
+	assert.Equal(t, &AuditExportCheckpoint{Timestamp: 3000, EventIDs: []string{"3"}}, checkpoint)
+
+	// A new event with the same timestamp as the high-water mark is still exported.
+	items = append([]interface{}{
+		map[string]interface{}{"id": "4", "category": "RULE_DELETED", "timestamp": 3000},
+	}, items...)
+
+	var jsonlOut bytes.Buffer
+	n, err = client.Audit.ExportAuditEvents(context.Background(), NewJSONLSink(&jsonlOut), store)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Contains(t, jsonlOut.String(), `"id":"4"`)
+
+	checkpoint, err = store.Load()
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"3", "4"}, checkpoint.EventIDs)
+}
+
+func TestCSVSinkDataKeys(t *testing.T) {
+	var out bytes.Buffer
+	sink := NewCSVSink(&out, "rule.name", "rule.enabled", "count", "tags")
+
+	events := []*AuditEvent{
+		{ID: "1", Data: json.RawMessage(`{"rule":{"name":"r","enabled":true},"count":1.5,"tags":["a","b"],"note":null}`)},
+		{ID: "2", Data: json.RawMessage(`{"count":2}`)},
+		{ID: "3"},
+	}
+	for _, event := range events {
+		assert.NoError(t, sink.Write(event))
+	}
+	assert.NoError(t, sink.Flush())
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	assert.Equal(t, []string{
+		"id,resourceType,resourceId,category,timestamp,performedByUserId,data.rule.name,data.rule.enabled,data.count,data.tags,otherData",
+		`1,,,,1970-01-01T00:00:00Z,,r,true,1.5,"[""a"",""b""]","{""note"":""""}"`,
+		`2,,,,1970-01-01T00:00:00Z,,,,2,,`,
+		`3,,,,1970-01-01T00:00:00Z,,,,,,`,
+	}, lines)
+}
+
+func TestCSVSinkEmpty(t *testing.T) {
+	var out bytes.Buffer
+	assert.NoError(t, NewCSVSink(&out).Flush())
+	assert.Equal(t, "id,resourceType,resourceId,category,timestamp,performedByUserId,otherData\n", out.String())
+}
+
+// failingSink fails every write after the first n.
+type failingSink struct {
+	AuditEventSink
+	n int
+}
+
+func (s *failingSink) Write(event *AuditEvent) error {
+	if s.n == 0 {
+		return errors.New("sink failed")
+	}
+	s.n--
+	return s.AuditEventSink.Write(event)
+}
+
+func TestExportAuditEventsStreamsRows(t *testing.T) {
+	client := newGraphQLTestClient(t, graphQLHandler{
+		"GetAuditEventsForAccount": func(vars map[string]interface{}) interface{} {
+			return map[string]interface{}{
+				"getAuditEventsForAccount": map[string]interface{}{
+					"items": []interface{}{
+						map[string]interface{}{"id": "2", "category": "RULE_UPDATED", "timestamp": 2000},
+						map[string]interface{}{"id": "1", "category": "RULE_CREATED", "timestamp": 1000},
+					},
+					"pageInfo": map[string]interface{}{"hasNextPage": false},
+				},
+			}
+		},
+	})
+
+	store := NewFileAuditCheckpointStore(filepath.Join(t.TempDir(), "audit.json"))
+
+	var out bytes.Buffer
+	n, err := client.Audit.ExportAuditEvents(context.Background(), &failingSink{AuditEventSink: NewCSVSink(&out), n: 1}, store)
+	assert.EqualError(t, err, "sink failed")
+	assert.Equal(t, 1, n)
+
+	// The row written before the failure reached the writer without a Flush.
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	assert.Len(t, lines, 2)
+	assert.True(t, strings.HasPrefix(lines[1], "2,"))
+
+	checkpoint, err := store.Load()
+	assert.NoError(t, err)
+	assert.Nil(t, checkpoint, "a failed export must not advance the checkpoint")
+}
+
+func TestResourceHistory(t *testing.T) {
+	var variables []map[string]interface{}
+
+	client := newGraphQLTestClient(t, graphQLHandler{
+		"GetHistoryForResource": func(vars map[string]interface{}) interface{} {
+			variables = append(variables, vars)
+			if vars["cursor"] == nil {
+				return map[string]interface{}{
+					"getHistoryForResource": map[string]interface{}{
+						"items": []interface{}{map[string]interface{}{
+							"timestamp":         1000,
+							"performedByUserId": "alice",
+							"data": map[string]interface{}{
+								"before": map[string]interface{}{"id": "q1", "title": "Old", "tags": []interface{}{"a"}},
+								"after":  map[string]interface{}{"id": "q1", "title": "New", "tags": []interface{}{"a"}},
+							},
+						}},
+						"pageInfo": map[string]interface{}{"endCursor": "next", "hasNextPage": true},
+					},
+				}
+			}
+			return map[string]interface{}{
+				"getHistoryForResource": map[string]interface{}{
+					"items":    []interface{}{map[string]interface{}{"timestamp": 500, "data": map[string]interface{}{}}},
+					"pageInfo": map[string]interface{}{"hasNextPage": false},
+				},
+			}
+		},
+	})
+
+	items, err := client.Audit.ResourceHistory(context.Background(), AuditResourceTypeQuestion, "q1", AuditCategoryQuestionUpdated, 0).All()
+	assert.NoError(t, err)
+	assert.Len(t, items, 2)
+	assert.Equal(t, "QUESTION", variables[0]["resourceType"])
+	assert.Equal(t, "next", variables[1]["cursor"])
+
+	data, err := items[0].DecodeData()
+	assert.NoError(t, err)
+
+	update, ok := data.(*QuestionUpdatedData)
+	if assert.True(t, ok) {
+		assert.Equal(t, "New", update.After.Title)
+
+		changes, err := update.Changes()
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.PropertyChange{{Name: "title", Old: "Old", New: "New"}}, changes)
+	}
+}
+
+func TestDecodeAuditData(t *testing.T) {
+	type ruleDeleted struct {
+		Name string `json:"name"`
+	}
+	RegisterAuditDataDecoder(AuditCategoryRuleDeleted, DecodeAuditDataAs[ruleDeleted]())
+	t.Cleanup(func() {
+		auditDataDecodersMu.Lock()
+		delete(auditDataDecoders, AuditCategoryRuleDeleted)
+		auditDataDecodersMu.Unlock()
+	})
+
+	event := &AuditEvent{Category: AuditCategoryRuleDeleted, Data: []byte(`{"name":"old rule"}`)}
+	data, err := event.DecodeData()
+	assert.NoError(t, err)
+	assert.Equal(t, &ruleDeleted{Name: "old rule"}, data)
+
+	event = &AuditEvent{Category: AuditCategoryRuleUpdated, Data: []byte(`{"before":{"name":"a"},"after":{"name":"b"}}`)}
+	data, err = event.DecodeData()
+	assert.NoError(t, err)
+	assert.IsType(t, &RuleUpdatedData{}, data)
+
+	event = &AuditEvent{Category: "SOMETHING_NEW", Data: []byte(`{"x":1}`)}
+	data, err = event.DecodeData()
+	assert.NoError(t, err)
+	assert.Equal(t, json.RawMessage(`{"x":1}`), data)
+}
@@ -2,6 +2,7 @@ package jupiterone
 
 import (
 	"net/http"
+	"sync"
 	"time"
 
 	gql "github.com/Khan/genqlient/graphql"
@@ -15,6 +16,13 @@ type Config struct {
 	AccountID  string
 	Region     string
 	HTTPClient *http.Client
+
+	// GzipUploads compresses the request body of sync uploads with gzip.
+	GzipUploads bool
+	// GzipQueryResults asks for gzip encoded deferred query results. Go's default
+	// transport already does this and decompresses transparently, so this only
+	// matters for an HTTPClient whose transport has DisableCompression set.
+	GzipQueryResults bool
 }
 
 type Client struct {
@@ -27,6 +35,16 @@ type Client struct {
 	httpBaseURL       string
 	RetryTimeout      time.Duration
 
+	gzipUploads      bool
+	gzipQueryResults bool
+
+	// jobIDReportingInstances holds the ids of the integration instances whose
+	// invocations have reported a job id; see IntegrationService.InvokeAndWait.
+	jobIDReportingInstances sync.Map
+
+	// config is kept so that derived clients can be built with the same settings.
+	config Config
+
 	Entity          *EntityService
 	Rule            *RuleService
 	Question        *QuestionService
@@ -111,6 +129,11 @@ func NewClient(config *Config) (*Client, error) {
 		httpClient:    httpClient,
 		httpBaseURL:   config.getHTTPEndpoint(),
 		RetryTimeout:  time.Minute,
+
+		gzipUploads:      config.GzipUploads,
+		gzipQueryResults: config.GzipQueryResults,
+
+		config: *config,
 	}
 
 	// Pass around the single client to each service
@@ -1,8 +1,14 @@
 package jupiterone
 
 import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
 	"testing"
 
+	gql "github.com/Khan/genqlient/graphql"
+	"github.com/machinebox/graphql"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -20,3 +26,52 @@ func TestGetGraphqlEndpointNoOverride(t *testing.T) {
 	endpoint := config.getGraphQLEndpoint()
 	assert.Equal(t, endpoint, "https://api.dev.jupiterone.io/graphql", "Endpoints should match")
 }
+
+// graphQLHandler is a fake GraphQL API that answers requests by operation name.
+type graphQLHandler map[string]func(variables map[string]interface{}) interface{}
+
+var operationNameRe = regexp.MustCompile(`(?:query|mutation)\s+(\w+)`)
+
+func (h graphQLHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Query         string                 `json:"query"`
+		OperationName string                 `json:"operationName"`
+		Variables     map[string]interface{} `json:"variables"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	name := req.OperationName
+	if match := operationNameRe.FindStringSubmatch(req.Query); name == "" && match != nil {
+		name = match[1]
+	}
+
+	resolve, ok := h[name]
+	if !ok {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"errors": []interface{}{map[string]interface{}{"message": "unexpected operation " + name}},
+		})
+		return
+	}
+
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": resolve(req.Variables)})
+}
+
+// newGraphQLTestClient returns a client whose GraphQL requests are answered by handler.
+func newGraphQLTestClient(t *testing.T, handler graphQLHandler) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(&Config{APIKey: "a", AccountID: "a"})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	client.gqlClient = gql.NewClient(server.URL, server.Client())
+	client.graphqlClient = graphql.NewClient(server.URL)
+
+	return client
+}
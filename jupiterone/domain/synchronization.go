@@ -1,11 +1,98 @@
 package domain
 
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrInvalidStartParams = errors.New("invalid sync job start params")
+
+// Sources accepted by the synchronization API.
+const (
+	SyncSourceAPI                = "api"
+	SyncSourceIntegrationManaged = "integration-managed"
+)
+
+// SyncMode controls what the persister does with data that was not uploaded
+// as part of a sync job.
+type SyncMode string
+
+const (
+	// SyncModeDiff deletes everything owned by the job's scope or integration
+	// instance that was not uploaded. It is the server default.
+	SyncModeDiff SyncMode = "DIFF"
+	// SyncModeCreateOrUpdate creates or updates the uploaded data and never deletes.
+	SyncModeCreateOrUpdate SyncMode = "CREATE_OR_UPDATE"
+)
+
+// SyncJobStatus is the lifecycle state of a sync job.
+type SyncJobStatus string
+
+const (
+	SyncJobStatusAwaitingUploads         SyncJobStatus = "AWAITING_UPLOADS"
+	SyncJobStatusFinalizePending         SyncJobStatus = "FINALIZE_PENDING"
+	SyncJobStatusFinalizingEntities      SyncJobStatus = "FINALIZING_ENTITIES"
+	SyncJobStatusFinalizingRelationships SyncJobStatus = "FINALIZING_RELATIONSHIPS"
+	SyncJobStatusAborted                 SyncJobStatus = "ABORTED"
+	SyncJobStatusFinished                SyncJobStatus = "FINISHED"
+	SyncJobStatusUnknown                 SyncJobStatus = "UNKNOWN"
+	SyncJobStatusError                   SyncJobStatus = "ERROR"
+)
+
+// IsTerminal reports whether a job in this status will not change any further.
+func (s SyncJobStatus) IsTerminal() bool {
+	switch s {
+	case SyncJobStatusAborted, SyncJobStatusFinished, SyncJobStatusError:
+		return true
+	default:
+		return false
+	}
+}
+
 type StartParams struct {
-	Source           string `json:"source,omitempty"`
-	Scope            string `json:"scope,omitempty"`
-	SyncMode         string `json:"syncMode,omitempty"`
-	InstanceID       string `json:"integrationInstanceId,omitempty"`
-	IgnoreDuplicates bool   `json:"-"`
+	Source           string   `json:"source,omitempty"`
+	Scope            string   `json:"scope,omitempty"`
+	SyncMode         SyncMode `json:"syncMode,omitempty"`
+	InstanceID       string   `json:"integrationInstanceId,omitempty"`
+	IgnoreDuplicates bool     `json:"-"`
+}
+
+// NewScopedDiffStartParams returns the StartParams for the common "scope-owned diff sync":
+// the uploaded data becomes the complete contents of scope, so anything previously
+// synchronized into scope that is missing from the upload is deleted when the job is finalized.
+//
+// Use a scope that is unique to the process producing the data, otherwise each
+// producer will delete the other's entities.
+func NewScopedDiffStartParams(scope string) StartParams {
+	return StartParams{
+		Source:   SyncSourceAPI,
+		Scope:    scope,
+		SyncMode: SyncModeDiff,
+	}
+}
+
+// Validate checks that the sync mode is known and that the job is owned by a
+// scope or an integration instance when deletions are possible. An empty
+// SyncMode is validated as SyncModeDiff because that is what the server uses.
+func (p StartParams) Validate() error {
+	switch p.SyncMode {
+	case "", SyncModeDiff:
+		if p.Scope == "" && p.InstanceID == "" {
+			return fmt.Errorf("%w: %s sync requires a scope or an integration instance id", ErrInvalidStartParams, SyncModeDiff)
+		}
+		if p.Scope != "" && p.InstanceID != "" {
+			return fmt.Errorf("%w: %s sync must set only one of scope and integration instance id", ErrInvalidStartParams, SyncModeDiff)
+		}
+	case SyncModeCreateOrUpdate:
+	default:
+		return fmt.Errorf("%w: unknown sync mode %q", ErrInvalidStartParams, p.SyncMode)
+	}
+
+	if p.Source == SyncSourceIntegrationManaged && p.InstanceID == "" {
+		return fmt.Errorf("%w: source %s requires an integration instance id", ErrInvalidStartParams, SyncSourceIntegrationManaged)
+	}
+
+	return nil
 }
 
 type SynchronizationJobOutput struct {
@@ -13,10 +100,10 @@ type SynchronizationJobOutput struct {
 	Scope          string
 	AccountID      string
 	ID             string `json:"id"`
-	Status         string
+	Status         SyncJobStatus
 	StartTimestamp int
 	DurationMs     int
-	DeletionMode   string
+	DeletionMode   SyncMode
 	Done           bool
 	TTL            int
 
@@ -72,3 +159,59 @@ type SyncPayload struct {
 	Entities      []interface{} `json:"entities,omitempty"`
 	Relationships []interface{} `json:"relationships,omitempty"`
 }
+
+// SyncCheckpoint records how far a resumable sync job has progressed so that
+// an interrupted upload can continue from the next chunk.
+type SyncCheckpoint struct {
+	JobID                      string `json:"jobId"`
+	EntityChunksUploaded       int    `json:"entityChunksUploaded"`
+	RelationshipChunksUploaded int    `json:"relationshipChunksUploaded"`
+}
+
+// SyncPlan describes what a DIFF sync of a payload into a scope would change.
+type SyncPlan struct {
+	Scope         string
+	Entities      SyncPlanChanges
+	Relationships SyncPlanChanges
+}
+
+// SyncPlanChanges lists the creates, updates and deletes for either entities
+// or relationships. Each list is sorted by key.
+type SyncPlanChanges struct {
+	Creates []SyncPlanItem
+	Updates []SyncPlanUpdate
+	Deletes []SyncPlanItem
+}
+
+// SyncPlanItem is an entity or relationship identified by its _key.
+// Properties excludes the underscore-prefixed metadata properties.
+type SyncPlanItem struct {
+	Key        string
+	Type       string
+	Properties map[string]interface{}
+}
+
+// SyncPlanUpdate is an existing entity or relationship whose properties would change.
+type SyncPlanUpdate struct {
+	Key     string
+	Type    string
+	Changes []PropertyChange
+}
+
+// PropertyChange is a single property difference. Old is nil for an added
+// property and New is nil for a removed property.
+type PropertyChange struct {
+	Name string
+	Old  interface{}
+	New  interface{}
+}
+
+// IsEmpty reports whether applying the plan would change nothing.
+func (p *SyncPlan) IsEmpty() bool {
+	return p.Entities.IsEmpty() && p.Relationships.IsEmpty()
+}
+
+// IsEmpty reports whether there are no creates, updates or deletes.
+func (c SyncPlanChanges) IsEmpty() bool {
+	return len(c.Creates) == 0 && len(c.Updates) == 0 && len(c.Deletes) == 0
+}
@@ -9,6 +9,120 @@ import (
 	"github.com/Khan/genqlient/graphql"
 )
 
+// BulkUpdateIntegrationInstancesBulkUpdateIntegrationInstancesBulkUpdateIntegrationInstancesOutput includes the requested fields of the GraphQL type BulkUpdateIntegrationInstancesOutput.
+type BulkUpdateIntegrationInstancesBulkUpdateIntegrationInstancesBulkUpdateIntegrationInstancesOutput struct {
+	Success   int      `json:"success"`
+	Failed    int      `json:"failed"`
+	FailedIds []string `json:"failedIds"`
+}
+
+// GetSuccess returns BulkUpdateIntegrationInstancesBulkUpdateIntegrationInstancesBulkUpdateIntegrationInstancesOutput.Success, and is useful for accessing the field via an interface.
+func (v *BulkUpdateIntegrationInstancesBulkUpdateIntegrationInstancesBulkUpdateIntegrationInstancesOutput) GetSuccess() int {
+	return v.Success
+}
+
+// GetFailed returns BulkUpdateIntegrationInstancesBulkUpdateIntegrationInstancesBulkUpdateIntegrationInstancesOutput.Failed, and is useful for accessing the field via an interface.
+func (v *BulkUpdateIntegrationInstancesBulkUpdateIntegrationInstancesBulkUpdateIntegrationInstancesOutput) GetFailed() int {
+	return v.Failed
+}
+
+// GetFailedIds returns BulkUpdateIntegrationInstancesBulkUpdateIntegrationInstancesBulkUpdateIntegrationInstancesOutput.FailedIds, and is useful for accessing the field via an interface.
+func (v *BulkUpdateIntegrationInstancesBulkUpdateIntegrationInstancesBulkUpdateIntegrationInstancesOutput) GetFailedIds() []string {
+	return v.FailedIds
+}
+
+// BulkUpdateIntegrationInstancesResponse is returned by BulkUpdateIntegrationInstances on success.
+type BulkUpdateIntegrationInstancesResponse struct {
+	BulkUpdateIntegrationInstances BulkUpdateIntegrationInstancesBulkUpdateIntegrationInstancesBulkUpdateIntegrationInstancesOutput `json:"bulkUpdateIntegrationInstances"`
+}
+
+// GetBulkUpdateIntegrationInstances returns BulkUpdateIntegrationInstancesResponse.BulkUpdateIntegrationInstances, and is useful for accessing the field via an interface.
+func (v *BulkUpdateIntegrationInstancesResponse) GetBulkUpdateIntegrationInstances() BulkUpdateIntegrationInstancesBulkUpdateIntegrationInstancesBulkUpdateIntegrationInstancesOutput {
+	return v.BulkUpdateIntegrationInstances
+}
+
+// ConfigFieldOption includes the requested fields of the GraphQL type ConfigFieldOption.
+type ConfigFieldOption struct {
+	Value   string `json:"value"`
+	Label   string `json:"label"`
+	Default bool   `json:"default"`
+}
+
+// GetValue returns ConfigFieldOption.Value, and is useful for accessing the field via an interface.
+func (v *ConfigFieldOption) GetValue() string { return v.Value }
+
+// GetLabel returns ConfigFieldOption.Label, and is useful for accessing the field via an interface.
+func (v *ConfigFieldOption) GetLabel() string { return v.Label }
+
+// GetDefault returns ConfigFieldOption.Default, and is useful for accessing the field via an interface.
+func (v *ConfigFieldOption) GetDefault() bool { return v.Default }
+
+// ConfigFieldSpec includes the requested fields of the GraphQL type ConfigField.
+type ConfigFieldSpec struct {
+	Key          string                  `json:"key"`
+	DisplayName  string                  `json:"displayName"`
+	Description  string                  `json:"description"`
+	Type         string                  `json:"type"`
+	Format       string                  `json:"format"`
+	DefaultValue json.RawMessage         `json:"defaultValue"`
+	Mask         bool                    `json:"mask"`
+	Optional     bool                    `json:"optional"`
+	Immutable    bool                    `json:"immutable"`
+	Readonly     bool                    `json:"readonly"`
+	Computed     bool                    `json:"computed"`
+	Options      []ConfigFieldOption     `json:"options"`
+	ConfigFields []NestedConfigFieldSpec `json:"configFields"`
+}
+
+// GetKey returns ConfigFieldSpec.Key, and is useful for accessing the field via an interface.
+func (v *ConfigFieldSpec) GetKey() string { return v.Key }
+
+// GetDisplayName returns ConfigFieldSpec.DisplayName, and is useful for accessing the field via an interface.
+func (v *ConfigFieldSpec) GetDisplayName() string { return v.DisplayName }
+
+// GetDescription returns ConfigFieldSpec.Description, and is useful for accessing the field via an interface.
+func (v *ConfigFieldSpec) GetDescription() string { return v.Description }
+
+// GetType returns ConfigFieldSpec.Type, and is useful for accessing the field via an interface.
+func (v *ConfigFieldSpec) GetType() string { return v.Type }
+
+// GetFormat returns ConfigFieldSpec.Format, and is useful for accessing the field via an interface.
+func (v *ConfigFieldSpec) GetFormat() string { return v.Format }
+
+// GetDefaultValue returns ConfigFieldSpec.DefaultValue, and is useful for accessing the field via an interface.
+func (v *ConfigFieldSpec) GetDefaultValue() json.RawMessage { return v.DefaultValue }
+
+// GetMask returns ConfigFieldSpec.Mask, and is useful for accessing the field via an interface.
+func (v *ConfigFieldSpec) GetMask() bool { return v.Mask }
+
+// GetOptional returns ConfigFieldSpec.Optional, and is useful for accessing the field via an interface.
+func (v *ConfigFieldSpec) GetOptional() bool { return v.Optional }
+
+// GetImmutable returns ConfigFieldSpec.Immutable, and is useful for accessing the field via an interface.
+func (v *ConfigFieldSpec) GetImmutable() bool { return v.Immutable }
+
+// GetReadonly returns ConfigFieldSpec.Readonly, and is useful for accessing the field via an interface.
+func (v *ConfigFieldSpec) GetReadonly() bool { return v.Readonly }
+
+// GetComputed returns ConfigFieldSpec.Computed, and is useful for accessing the field via an interface.
+func (v *ConfigFieldSpec) GetComputed() bool { return v.Computed }
+
+// GetOptions returns ConfigFieldSpec.Options, and is useful for accessing the field via an interface.
+func (v *ConfigFieldSpec) GetOptions() []ConfigFieldOption { return v.Options }
+
+// GetConfigFields returns ConfigFieldSpec.ConfigFields, and is useful for accessing the field via an interface.
+func (v *ConfigFieldSpec) GetConfigFields() []NestedConfigFieldSpec { return v.ConfigFields }
+
+// CountIntegrationInstancesResponse is returned by CountIntegrationInstances on success.
+type CountIntegrationInstancesResponse struct {
+	IntegrationInstanceCount int `json:"integrationInstanceCount"`
+}
+
+// GetIntegrationInstanceCount returns CountIntegrationInstancesResponse.IntegrationInstanceCount, and is useful for accessing the field via an interface.
+func (v *CountIntegrationInstancesResponse) GetIntegrationInstanceCount() int {
+	return v.IntegrationInstanceCount
+}
+
 // CreateInstanceCreateIntegrationInstance includes the requested fields of the GraphQL type IntegrationInstance.
 type CreateInstanceCreateIntegrationInstance struct {
 	Id                      string                     `json:"id"`
@@ -94,6 +208,16 @@ func (v *CreateIntegrationInstanceInput) GetConfig() *map[string]interface{} { r
 // GetOffsiteComplete returns CreateIntegrationInstanceInput.OffsiteComplete, and is useful for accessing the field via an interface.
 func (v *CreateIntegrationInstanceInput) GetOffsiteComplete() *bool { return v.OffsiteComplete }
 
+// CreateIntegrationInstanceTokenResponse is returned by CreateIntegrationInstanceToken on success.
+type CreateIntegrationInstanceTokenResponse struct {
+	CreateIntegrationInstanceToken IntegrationInstanceToken `json:"createIntegrationInstanceToken"`
+}
+
+// GetCreateIntegrationInstanceToken returns CreateIntegrationInstanceTokenResponse.CreateIntegrationInstanceToken, and is useful for accessing the field via an interface.
+func (v *CreateIntegrationInstanceTokenResponse) GetCreateIntegrationInstanceToken() IntegrationInstanceToken {
+	return v.CreateIntegrationInstanceToken
+}
+
 type DeferredResponseFormat string
 
 const (
@@ -150,14 +274,55 @@ const (
 	FilterTypeOr                 FilterType = "OR"
 )
 
+// FindIntegrationDefinitionResponse is returned by FindIntegrationDefinition on success.
+type FindIntegrationDefinitionResponse struct {
+	FindIntegrationDefinition IntegrationDefinition `json:"findIntegrationDefinition"`
+}
+
+// GetFindIntegrationDefinition returns FindIntegrationDefinitionResponse.FindIntegrationDefinition, and is useful for accessing the field via an interface.
+func (v *FindIntegrationDefinitionResponse) GetFindIntegrationDefinition() IntegrationDefinition {
+	return v.FindIntegrationDefinition
+}
+
+// GetIntegrationDefinitionConfigFieldsIntegrationDefinition includes the requested fields of the GraphQL type IntegrationDefinition.
+type GetIntegrationDefinitionConfigFieldsIntegrationDefinition struct {
+	Id              string            `json:"id"`
+	IntegrationType string            `json:"integrationType"`
+	ConfigFields    []ConfigFieldSpec `json:"configFields"`
+}
+
+// GetId returns GetIntegrationDefinitionConfigFieldsIntegrationDefinition.Id, and is useful for accessing the field via an interface.
+func (v *GetIntegrationDefinitionConfigFieldsIntegrationDefinition) GetId() string { return v.Id }
+
+// GetIntegrationType returns GetIntegrationDefinitionConfigFieldsIntegrationDefinition.IntegrationType, and is useful for accessing the field via an interface.
+func (v *GetIntegrationDefinitionConfigFieldsIntegrationDefinition) GetIntegrationType() string {
+	return v.IntegrationType
+}
+
+// GetConfigFields returns GetIntegrationDefinitionConfigFieldsIntegrationDefinition.ConfigFields, and is useful for accessing the field via an interface.
+func (v *GetIntegrationDefinitionConfigFieldsIntegrationDefinition) GetConfigFields() []ConfigFieldSpec {
+	return v.ConfigFields
+}
+
+// GetIntegrationDefinitionConfigFieldsResponse is returned by GetIntegrationDefinitionConfigFields on success.
+type GetIntegrationDefinitionConfigFieldsResponse struct {
+	IntegrationDefinition GetIntegrationDefinitionConfigFieldsIntegrationDefinition `json:"integrationDefinition"`
+}
+
+// GetIntegrationDefinition returns GetIntegrationDefinitionConfigFieldsResponse.IntegrationDefinition, and is useful for accessing the field via an interface.
+func (v *GetIntegrationDefinitionConfigFieldsResponse) GetIntegrationDefinition() GetIntegrationDefinitionConfigFieldsIntegrationDefinition {
+	return v.IntegrationDefinition
+}
+
 // GetIntegrationDefinitionIntegrationDefinition includes the requested fields of the GraphQL type IntegrationDefinition.
 type GetIntegrationDefinitionIntegrationDefinition struct {
-	Id               string   `json:"id"`
-	IntegrationType  string   `json:"integrationType"`
-	IntegrationClass []string `json:"integrationClass"`
-	Name             string   `json:"name"`
-	Title            string   `json:"title"`
-	RepoWebLink      string   `json:"repoWebLink"`
+	Id               string                                         `json:"id"`
+	IntegrationType  string                                         `json:"integrationType"`
+	IntegrationClass []string                                       `json:"integrationClass"`
+	Name             string                                         `json:"name"`
+	Title            string                                         `json:"title"`
+	RepoWebLink      string                                         `json:"repoWebLink"`
+	ConfigFields     []IntegrationDefinitionConfigFieldsConfigField `json:"configFields"`
 }
 
 // GetId returns GetIntegrationDefinitionIntegrationDefinition.Id, and is useful for accessing the field via an interface.
@@ -182,6 +347,11 @@ func (v *GetIntegrationDefinitionIntegrationDefinition) GetTitle() string { retu
 // GetRepoWebLink returns GetIntegrationDefinitionIntegrationDefinition.RepoWebLink, and is useful for accessing the field via an interface.
 func (v *GetIntegrationDefinitionIntegrationDefinition) GetRepoWebLink() string { return v.RepoWebLink }
 
+// GetConfigFields returns GetIntegrationDefinitionIntegrationDefinition.ConfigFields, and is useful for accessing the field via an interface.
+func (v *GetIntegrationDefinitionIntegrationDefinition) GetConfigFields() []IntegrationDefinitionConfigFieldsConfigField {
+	return v.ConfigFields
+}
+
 // GetIntegrationDefinitionResponse is returned by GetIntegrationDefinition on success.
 type GetIntegrationDefinitionResponse struct {
 	IntegrationDefinition GetIntegrationDefinitionIntegrationDefinition `json:"integrationDefinition"`
@@ -359,6 +529,14 @@ func (v *GetIntegrationInstanceResponse) GetIntegrationInstance() GetIntegration
 	return v.IntegrationInstance
 }
 
+// GetIntegrationJobResponse is returned by GetIntegrationJob on success.
+type GetIntegrationJobResponse struct {
+	IntegrationJob IntegrationJob `json:"integrationJob"`
+}
+
+// GetIntegrationJob returns GetIntegrationJobResponse.IntegrationJob, and is useful for accessing the field via an interface.
+func (v *GetIntegrationJobResponse) GetIntegrationJob() IntegrationJob { return v.IntegrationJob }
+
 // IntegrationDefinition includes the requested fields of the GraphQL type IntegrationDefinition.
 type IntegrationDefinition struct {
 	Id               string                                         `json:"id"`
@@ -395,12 +573,16 @@ func (v *IntegrationDefinition) GetConfigFields() []IntegrationDefinitionConfigF
 
 // IntegrationDefinitionConfigFieldsConfigField includes the requested fields of the GraphQL type ConfigField.
 type IntegrationDefinitionConfigFieldsConfigField struct {
-	Key string `json:"key"`
+	Key  string `json:"key"`
+	Mask bool   `json:"mask"`
 }
 
 // GetKey returns IntegrationDefinitionConfigFieldsConfigField.Key, and is useful for accessing the field via an interface.
 func (v *IntegrationDefinitionConfigFieldsConfigField) GetKey() string { return v.Key }
 
+// GetMask returns IntegrationDefinitionConfigFieldsConfigField.Mask, and is useful for accessing the field via an interface.
+func (v *IntegrationDefinitionConfigFieldsConfigField) GetMask() bool { return v.Mask }
+
 // IntegrationDefinitionsIntegrationDefinitionsListIntegrationDefinitionsResult includes the requested fields of the GraphQL type ListIntegrationDefinitionsResult.
 type IntegrationDefinitionsIntegrationDefinitionsListIntegrationDefinitionsResult struct {
 	Definitions []IntegrationDefinition `json:"definitions"`
@@ -457,6 +639,8 @@ type IntegrationInstance struct {
 	Name                          string                                   `json:"name"`
 	Description                   string                                   `json:"description"`
 	SourceIntegrationInstanceId   string                                   `json:"sourceIntegrationInstanceId"`
+	IntegrationDefinitionId       string                                   `json:"integrationDefinitionId"`
+	Config                        map[string]interface{}                   `json:"config"`
 	PollingInterval               IntegrationPollingInterval               `json:"pollingInterval"`
 	PollingIntervalCronExpression PollingIntervalCronExpression            `json:"pollingIntervalCronExpression"`
 	IntegrationDefinition         IntegrationInstanceIntegrationDefinition `json:"integrationDefinition"`
@@ -476,6 +660,12 @@ func (v *IntegrationInstance) GetSourceIntegrationInstanceId() string {
 	return v.SourceIntegrationInstanceId
 }
 
+// GetIntegrationDefinitionId returns IntegrationInstance.IntegrationDefinitionId, and is useful for accessing the field via an interface.
+func (v *IntegrationInstance) GetIntegrationDefinitionId() string { return v.IntegrationDefinitionId }
+
+// GetConfig returns IntegrationInstance.Config, and is useful for accessing the field via an interface.
+func (v *IntegrationInstance) GetConfig() map[string]interface{} { return v.Config }
+
 // GetPollingInterval returns IntegrationInstance.PollingInterval, and is useful for accessing the field via an interface.
 func (v *IntegrationInstance) GetPollingInterval() IntegrationPollingInterval {
 	return v.PollingInterval
@@ -491,6 +681,34 @@ func (v *IntegrationInstance) GetIntegrationDefinition() IntegrationInstanceInte
 	return v.IntegrationDefinition
 }
 
+// IntegrationInstanceHealth includes the requested fields of the GraphQL type IntegrationInstance.
+type IntegrationInstanceHealth struct {
+	Id                      string                  `json:"id"`
+	Name                    string                  `json:"name"`
+	IntegrationDefinitionId string                  `json:"integrationDefinitionId"`
+	LastJob                 IntegrationInstanceJobs `json:"lastJob"`
+	LastSuccessfulJob       IntegrationInstanceJobs `json:"lastSuccessfulJob"`
+}
+
+// GetId returns IntegrationInstanceHealth.Id, and is useful for accessing the field via an interface.
+func (v *IntegrationInstanceHealth) GetId() string { return v.Id }
+
+// GetName returns IntegrationInstanceHealth.Name, and is useful for accessing the field via an interface.
+func (v *IntegrationInstanceHealth) GetName() string { return v.Name }
+
+// GetIntegrationDefinitionId returns IntegrationInstanceHealth.IntegrationDefinitionId, and is useful for accessing the field via an interface.
+func (v *IntegrationInstanceHealth) GetIntegrationDefinitionId() string {
+	return v.IntegrationDefinitionId
+}
+
+// GetLastJob returns IntegrationInstanceHealth.LastJob, and is useful for accessing the field via an interface.
+func (v *IntegrationInstanceHealth) GetLastJob() IntegrationInstanceJobs { return v.LastJob }
+
+// GetLastSuccessfulJob returns IntegrationInstanceHealth.LastSuccessfulJob, and is useful for accessing the field via an interface.
+func (v *IntegrationInstanceHealth) GetLastSuccessfulJob() IntegrationInstanceJobs {
+	return v.LastSuccessfulJob
+}
+
 // IntegrationInstanceIntegrationDefinition includes the requested fields of the GraphQL type IntegrationDefinition.
 type IntegrationInstanceIntegrationDefinition struct {
 	Id               string   `json:"id"`
@@ -553,6 +771,14 @@ func (v *IntegrationInstanceJobValues) GetErrorsOccurred() bool { return v.Error
 // GetTypename returns IntegrationInstanceJobValues.Typename, and is useful for accessing the field via an interface.
 func (v *IntegrationInstanceJobValues) GetTypename() string { return v.Typename }
 
+// IntegrationInstanceJobs includes the requested fields of the GraphQL type IntegrationJobsResult.
+type IntegrationInstanceJobs struct {
+	Jobs []IntegrationJob `json:"jobs"`
+}
+
+// GetJobs returns IntegrationInstanceJobs.Jobs, and is useful for accessing the field via an interface.
+func (v *IntegrationInstanceJobs) GetJobs() []IntegrationJob { return v.Jobs }
+
 type IntegrationInstanceRelationship string
 
 const (
@@ -561,6 +787,26 @@ const (
 	IntegrationInstanceRelationshipStandalone IntegrationInstanceRelationship = "STANDALONE"
 )
 
+// IntegrationInstanceToken includes the requested fields of the GraphQL type IntegrationInstanceToken.
+type IntegrationInstanceToken struct {
+	TokenId   string `json:"tokenId"`
+	Token     string `json:"token"`
+	CreatedAt int    `json:"createdAt"`
+	ExpiresAt int    `json:"expiresAt"`
+}
+
+// GetTokenId returns IntegrationInstanceToken.TokenId, and is useful for accessing the field via an interface.
+func (v *IntegrationInstanceToken) GetTokenId() string { return v.TokenId }
+
+// GetToken returns IntegrationInstanceToken.Token, and is useful for accessing the field via an interface.
+func (v *IntegrationInstanceToken) GetToken() string { return v.Token }
+
+// GetCreatedAt returns IntegrationInstanceToken.CreatedAt, and is useful for accessing the field via an interface.
+func (v *IntegrationInstanceToken) GetCreatedAt() int { return v.CreatedAt }
+
+// GetExpiresAt returns IntegrationInstanceToken.ExpiresAt, and is useful for accessing the field via an interface.
+func (v *IntegrationInstanceToken) GetExpiresAt() int { return v.ExpiresAt }
+
 // IntegrationInstanceValues includes the GraphQL fields of IntegrationInstance requested by the fragment IntegrationInstanceValues.
 type IntegrationInstanceValues struct {
 	Id                            string                                             `json:"id"`
@@ -770,6 +1016,58 @@ func (v *IntegrationInstanceValuesJobsIntegrationJobsResultJobsIntegrationJob) _
 	return &retval, nil
 }
 
+// IntegrationInstancesHealthIntegrationInstancesListIntegrationInstancesResult includes the requested fields of the GraphQL type ListIntegrationInstancesResult.
+type IntegrationInstancesHealthIntegrationInstancesListIntegrationInstancesResult struct {
+	Instances []IntegrationInstanceHealth `json:"instances"`
+	PageInfo  PageInfo                    `json:"pageInfo"`
+}
+
+// GetInstances returns IntegrationInstancesHealthIntegrationInstancesListIntegrationInstancesResult.Instances, and is useful for accessing the field via an interface.
+func (v *IntegrationInstancesHealthIntegrationInstancesListIntegrationInstancesResult) GetInstances() []IntegrationInstanceHealth {
+	return v.Instances
+}
+
+// GetPageInfo returns IntegrationInstancesHealthIntegrationInstancesListIntegrationInstancesResult.PageInfo, and is useful for accessing the field via an interface.
+func (v *IntegrationInstancesHealthIntegrationInstancesListIntegrationInstancesResult) GetPageInfo() PageInfo {
+	return v.PageInfo
+}
+
+// IntegrationInstancesHealthResponse is returned by IntegrationInstancesHealth on success.
+type IntegrationInstancesHealthResponse struct {
+	IntegrationInstances IntegrationInstancesHealthIntegrationInstancesListIntegrationInstancesResult `json:"integrationInstances"`
+}
+
+// GetIntegrationInstances returns IntegrationInstancesHealthResponse.IntegrationInstances, and is useful for accessing the field via an interface.
+func (v *IntegrationInstancesHealthResponse) GetIntegrationInstances() IntegrationInstancesHealthIntegrationInstancesListIntegrationInstancesResult {
+	return v.IntegrationInstances
+}
+
+// IntegrationInstancesStatusIntegrationInstancesStatusIntegrationInstancesStatusResult includes the requested fields of the GraphQL type IntegrationInstancesStatusResult.
+type IntegrationInstancesStatusIntegrationInstancesStatusIntegrationInstancesStatusResult struct {
+	Status               string                 `json:"status"`
+	StatusesByDefinition map[string]interface{} `json:"statusesByDefinition"`
+}
+
+// GetStatus returns IntegrationInstancesStatusIntegrationInstancesStatusIntegrationInstancesStatusResult.Status, and is useful for accessing the field via an interface.
+func (v *IntegrationInstancesStatusIntegrationInstancesStatusIntegrationInstancesStatusResult) GetStatus() string {
+	return v.Status
+}
+
+// GetStatusesByDefinition returns IntegrationInstancesStatusIntegrationInstancesStatusIntegrationInstancesStatusResult.StatusesByDefinition, and is useful for accessing the field via an interface.
+func (v *IntegrationInstancesStatusIntegrationInstancesStatusIntegrationInstancesStatusResult) GetStatusesByDefinition() map[string]interface{} {
+	return v.StatusesByDefinition
+}
+
+// IntegrationInstancesStatusResponse is returned by IntegrationInstancesStatus on success.
+type IntegrationInstancesStatusResponse struct {
+	IntegrationInstancesStatus IntegrationInstancesStatusIntegrationInstancesStatusIntegrationInstancesStatusResult `json:"integrationInstancesStatus"`
+}
+
+// GetIntegrationInstancesStatus returns IntegrationInstancesStatusResponse.IntegrationInstancesStatus, and is useful for accessing the field via an interface.
+func (v *IntegrationInstancesStatusResponse) GetIntegrationInstancesStatus() IntegrationInstancesStatusIntegrationInstancesStatusIntegrationInstancesStatusResult {
+	return v.IntegrationInstancesStatus
+}
+
 // IntegrationJob includes the requested fields of the GraphQL type IntegrationJob.
 type IntegrationJob struct {
 	Id                    string               `json:"id"`
@@ -881,6 +1179,32 @@ func (v *ListEventsResponse) GetIntegrationEvents() ListEventsIntegrationEventsI
 	return v.IntegrationEvents
 }
 
+// ListIntegrationInstanceTokensIntegrationInstanceTokensListIntegrationInstanceTokensResult includes the requested fields of the GraphQL type ListIntegrationInstanceTokensResult.
+type ListIntegrationInstanceTokensIntegrationInstanceTokensListIntegrationInstanceTokensResult struct {
+	Tokens   []IntegrationInstanceToken `json:"tokens"`
+	PageInfo PageInfo                   `json:"pageInfo"`
+}
+
+// GetTokens returns ListIntegrationInstanceTokensIntegrationInstanceTokensListIntegrationInstanceTokensResult.Tokens, and is useful for accessing the field via an interface.
+func (v *ListIntegrationInstanceTokensIntegrationInstanceTokensListIntegrationInstanceTokensResult) GetTokens() []IntegrationInstanceToken {
+	return v.Tokens
+}
+
+// GetPageInfo returns ListIntegrationInstanceTokensIntegrationInstanceTokensListIntegrationInstanceTokensResult.PageInfo, and is useful for accessing the field via an interface.
+func (v *ListIntegrationInstanceTokensIntegrationInstanceTokensListIntegrationInstanceTokensResult) GetPageInfo() PageInfo {
+	return v.PageInfo
+}
+
+// ListIntegrationInstanceTokensResponse is returned by ListIntegrationInstanceTokens on success.
+type ListIntegrationInstanceTokensResponse struct {
+	IntegrationInstanceTokens ListIntegrationInstanceTokensIntegrationInstanceTokensListIntegrationInstanceTokensResult `json:"integrationInstanceTokens"`
+}
+
+// GetIntegrationInstanceTokens returns ListIntegrationInstanceTokensResponse.IntegrationInstanceTokens, and is useful for accessing the field via an interface.
+func (v *ListIntegrationInstanceTokensResponse) GetIntegrationInstanceTokens() ListIntegrationInstanceTokensIntegrationInstanceTokensListIntegrationInstanceTokensResult {
+	return v.IntegrationInstanceTokens
+}
+
 // ListIntegrationInstancesIntegrationInstancesListIntegrationInstancesResult includes the requested fields of the GraphQL type ListIntegrationInstancesResult.
 type ListIntegrationInstancesIntegrationInstancesListIntegrationInstancesResult struct {
 	Instances []IntegrationInstance `json:"instances"`
@@ -907,6 +1231,51 @@ func (v *ListIntegrationInstancesResponse) GetIntegrationInstances() ListIntegra
 	return v.IntegrationInstances
 }
 
+type ListIntegrationInstancesSearchFilter struct {
+	Name                        *string `json:"name,omitempty"`
+	IncludeOnlySourceInstances  *bool   `json:"includeOnlySourceInstances,omitempty"`
+	SourceIntegrationInstanceId *string `json:"sourceIntegrationInstanceId,omitempty"`
+}
+
+// GetName returns ListIntegrationInstancesSearchFilter.Name, and is useful for accessing the field via an interface.
+func (v *ListIntegrationInstancesSearchFilter) GetName() *string { return v.Name }
+
+// GetIncludeOnlySourceInstances returns ListIntegrationInstancesSearchFilter.IncludeOnlySourceInstances, and is useful for accessing the field via an interface.
+func (v *ListIntegrationInstancesSearchFilter) GetIncludeOnlySourceInstances() *bool {
+	return v.IncludeOnlySourceInstances
+}
+
+// GetSourceIntegrationInstanceId returns ListIntegrationInstancesSearchFilter.SourceIntegrationInstanceId, and is useful for accessing the field via an interface.
+func (v *ListIntegrationInstancesSearchFilter) GetSourceIntegrationInstanceId() *string {
+	return v.SourceIntegrationInstanceId
+}
+
+// ListJobsAcrossInstancesIntegrationJobsIntegrationJobsResult includes the requested fields of the GraphQL type IntegrationJobsResult.
+type ListJobsAcrossInstancesIntegrationJobsIntegrationJobsResult struct {
+	Jobs     []IntegrationJob `json:"jobs"`
+	PageInfo PageInfo         `json:"pageInfo"`
+}
+
+// GetJobs returns ListJobsAcrossInstancesIntegrationJobsIntegrationJobsResult.Jobs, and is useful for accessing the field via an interface.
+func (v *ListJobsAcrossInstancesIntegrationJobsIntegrationJobsResult) GetJobs() []IntegrationJob {
+	return v.Jobs
+}
+
+// GetPageInfo returns ListJobsAcrossInstancesIntegrationJobsIntegrationJobsResult.PageInfo, and is useful for accessing the field via an interface.
+func (v *ListJobsAcrossInstancesIntegrationJobsIntegrationJobsResult) GetPageInfo() PageInfo {
+	return v.PageInfo
+}
+
+// ListJobsAcrossInstancesResponse is returned by ListJobsAcrossInstances on success.
+type ListJobsAcrossInstancesResponse struct {
+	IntegrationJobs ListJobsAcrossInstancesIntegrationJobsIntegrationJobsResult `json:"integrationJobs"`
+}
+
+// GetIntegrationJobs returns ListJobsAcrossInstancesResponse.IntegrationJobs, and is useful for accessing the field via an interface.
+func (v *ListJobsAcrossInstancesResponse) GetIntegrationJobs() ListJobsAcrossInstancesIntegrationJobsIntegrationJobsResult {
+	return v.IntegrationJobs
+}
+
 // ListJobsIntegrationJobsIntegrationJobsResult includes the requested fields of the GraphQL type IntegrationJobsResult.
 type ListJobsIntegrationJobsIntegrationJobsResult struct {
 	Jobs     []IntegrationJob `json:"jobs"`
@@ -1011,6 +1380,58 @@ func (v *ListVerticesV2Response) GetListVerticesV2() ListVerticesV2ListVerticesV
 	return v.ListVerticesV2
 }
 
+// NestedConfigFieldSpec includes the requested fields of the GraphQL type ConfigField.
+type NestedConfigFieldSpec struct {
+	Key          string              `json:"key"`
+	DisplayName  string              `json:"displayName"`
+	Description  string              `json:"description"`
+	Type         string              `json:"type"`
+	Format       string              `json:"format"`
+	DefaultValue json.RawMessage     `json:"defaultValue"`
+	Mask         bool                `json:"mask"`
+	Optional     bool                `json:"optional"`
+	Immutable    bool                `json:"immutable"`
+	Readonly     bool                `json:"readonly"`
+	Computed     bool                `json:"computed"`
+	Options      []ConfigFieldOption `json:"options"`
+}
+
+// GetKey returns NestedConfigFieldSpec.Key, and is useful for accessing the field via an interface.
+func (v *NestedConfigFieldSpec) GetKey() string { return v.Key }
+
+// GetDisplayName returns NestedConfigFieldSpec.DisplayName, and is useful for accessing the field via an interface.
+func (v *NestedConfigFieldSpec) GetDisplayName() string { return v.DisplayName }
+
+// GetDescription returns NestedConfigFieldSpec.Description, and is useful for accessing the field via an interface.
+func (v *NestedConfigFieldSpec) GetDescription() string { return v.Description }
+
+// GetType returns NestedConfigFieldSpec.Type, and is useful for accessing the field via an interface.
+func (v *NestedConfigFieldSpec) GetType() string { return v.Type }
+
+// GetFormat returns NestedConfigFieldSpec.Format, and is useful for accessing the field via an interface.
+func (v *NestedConfigFieldSpec) GetFormat() string { return v.Format }
+
+// GetDefaultValue returns NestedConfigFieldSpec.DefaultValue, and is useful for accessing the field via an interface.
+func (v *NestedConfigFieldSpec) GetDefaultValue() json.RawMessage { return v.DefaultValue }
+
+// GetMask returns NestedConfigFieldSpec.Mask, and is useful for accessing the field via an interface.
+func (v *NestedConfigFieldSpec) GetMask() bool { return v.Mask }
+
+// GetOptional returns NestedConfigFieldSpec.Optional, and is useful for accessing the field via an interface.
+func (v *NestedConfigFieldSpec) GetOptional() bool { return v.Optional }
+
+// GetImmutable returns NestedConfigFieldSpec.Immutable, and is useful for accessing the field via an interface.
+func (v *NestedConfigFieldSpec) GetImmutable() bool { return v.Immutable }
+
+// GetReadonly returns NestedConfigFieldSpec.Readonly, and is useful for accessing the field via an interface.
+func (v *NestedConfigFieldSpec) GetReadonly() bool { return v.Readonly }
+
+// GetComputed returns NestedConfigFieldSpec.Computed, and is useful for accessing the field via an interface.
+func (v *NestedConfigFieldSpec) GetComputed() bool { return v.Computed }
+
+// GetOptions returns NestedConfigFieldSpec.Options, and is useful for accessing the field via an interface.
+func (v *NestedConfigFieldSpec) GetOptions() []ConfigFieldOption { return v.Options }
+
 // PageInfo includes the requested fields of the GraphQL type PageInfo.
 type PageInfo struct {
 	EndCursor   string `json:"endCursor"`
@@ -1088,6 +1509,52 @@ func (v *QueryV1Flags) GetRowMetadata() bool { return v.RowMetadata }
 // GetVariableResultSize returns QueryV1Flags.VariableResultSize, and is useful for accessing the field via an interface.
 func (v *QueryV1Flags) GetVariableResultSize() bool { return v.VariableResultSize }
 
+// RevokeIntegrationInstanceTokenResponse is returned by RevokeIntegrationInstanceToken on success.
+type RevokeIntegrationInstanceTokenResponse struct {
+	RevokeIntegrationInstanceToken RevokeIntegrationInstanceTokenRevokeIntegrationInstanceTokenDeletionResult `json:"revokeIntegrationInstanceToken"`
+}
+
+// GetRevokeIntegrationInstanceToken returns RevokeIntegrationInstanceTokenResponse.RevokeIntegrationInstanceToken, and is useful for accessing the field via an interface.
+func (v *RevokeIntegrationInstanceTokenResponse) GetRevokeIntegrationInstanceToken() RevokeIntegrationInstanceTokenRevokeIntegrationInstanceTokenDeletionResult {
+	return v.RevokeIntegrationInstanceToken
+}
+
+// RevokeIntegrationInstanceTokenRevokeIntegrationInstanceTokenDeletionResult includes the requested fields of the GraphQL type DeletionResult.
+type RevokeIntegrationInstanceTokenRevokeIntegrationInstanceTokenDeletionResult struct {
+	Success bool `json:"success"`
+}
+
+// GetSuccess returns RevokeIntegrationInstanceTokenRevokeIntegrationInstanceTokenDeletionResult.Success, and is useful for accessing the field via an interface.
+func (v *RevokeIntegrationInstanceTokenRevokeIntegrationInstanceTokenDeletionResult) GetSuccess() bool {
+	return v.Success
+}
+
+// SearchIntegrationInstancesIntegrationInstancesListIntegrationInstancesResult includes the requested fields of the GraphQL type ListIntegrationInstancesResult.
+type SearchIntegrationInstancesIntegrationInstancesListIntegrationInstancesResult struct {
+	Instances []IntegrationInstance `json:"instances"`
+	PageInfo  PageInfo              `json:"pageInfo"`
+}
+
+// GetInstances returns SearchIntegrationInstancesIntegrationInstancesListIntegrationInstancesResult.Instances, and is useful for accessing the field via an interface.
+func (v *SearchIntegrationInstancesIntegrationInstancesListIntegrationInstancesResult) GetInstances() []IntegrationInstance {
+	return v.Instances
+}
+
+// GetPageInfo returns SearchIntegrationInstancesIntegrationInstancesListIntegrationInstancesResult.PageInfo, and is useful for accessing the field via an interface.
+func (v *SearchIntegrationInstancesIntegrationInstancesListIntegrationInstancesResult) GetPageInfo() PageInfo {
+	return v.PageInfo
+}
+
+// SearchIntegrationInstancesResponse is returned by SearchIntegrationInstances on success.
+type SearchIntegrationInstancesResponse struct {
+	IntegrationInstances SearchIntegrationInstancesIntegrationInstancesListIntegrationInstancesResult `json:"integrationInstances"`
+}
+
+// GetIntegrationInstances returns SearchIntegrationInstancesResponse.IntegrationInstances, and is useful for accessing the field via an interface.
+func (v *SearchIntegrationInstancesResponse) GetIntegrationInstances() SearchIntegrationInstancesIntegrationInstancesListIntegrationInstancesResult {
+	return v.IntegrationInstances
+}
+
 type SortOrder string
 
 const (
@@ -1499,6 +1966,28 @@ func (v *VertexVertexEntityEntityCoreProperties) GetVersion() int { return v.Ver
 // GetDisplayName returns VertexVertexEntityEntityCoreProperties.DisplayName, and is useful for accessing the field via an interface.
 func (v *VertexVertexEntityEntityCoreProperties) GetDisplayName() string { return v.DisplayName }
 
+// __BulkUpdateIntegrationInstancesInput is used internally by genqlient
+type __BulkUpdateIntegrationInstancesInput struct {
+	Ids    []string                       `json:"ids"`
+	Update UpdateIntegrationInstanceInput `json:"update"`
+}
+
+// GetIds returns __BulkUpdateIntegrationInstancesInput.Ids, and is useful for accessing the field via an interface.
+func (v *__BulkUpdateIntegrationInstancesInput) GetIds() []string { return v.Ids }
+
+// GetUpdate returns __BulkUpdateIntegrationInstancesInput.Update, and is useful for accessing the field via an interface.
+func (v *__BulkUpdateIntegrationInstancesInput) GetUpdate() UpdateIntegrationInstanceInput {
+	return v.Update
+}
+
+// __CountIntegrationInstancesInput is used internally by genqlient
+type __CountIntegrationInstancesInput struct {
+	DefinitionId string `json:"definitionId,omitempty"`
+}
+
+// GetDefinitionId returns __CountIntegrationInstancesInput.DefinitionId, and is useful for accessing the field via an interface.
+func (v *__CountIntegrationInstancesInput) GetDefinitionId() string { return v.DefinitionId }
+
 // __CreateInstanceInput is used internally by genqlient
 type __CreateInstanceInput struct {
 	Instance CreateIntegrationInstanceInput `json:"instance"`
@@ -1507,6 +1996,14 @@ type __CreateInstanceInput struct {
 // GetInstance returns __CreateInstanceInput.Instance, and is useful for accessing the field via an interface.
 func (v *__CreateInstanceInput) GetInstance() CreateIntegrationInstanceInput { return v.Instance }
 
+// __CreateIntegrationInstanceTokenInput is used internally by genqlient
+type __CreateIntegrationInstanceTokenInput struct {
+	Id string `json:"id"`
+}
+
+// GetId returns __CreateIntegrationInstanceTokenInput.Id, and is useful for accessing the field via an interface.
+func (v *__CreateIntegrationInstanceTokenInput) GetId() string { return v.Id }
+
 // __DeleteIntegrationInstanceInput is used internally by genqlient
 type __DeleteIntegrationInstanceInput struct {
 	Id string `json:"id"`
@@ -1515,6 +2012,22 @@ type __DeleteIntegrationInstanceInput struct {
 // GetId returns __DeleteIntegrationInstanceInput.Id, and is useful for accessing the field via an interface.
 func (v *__DeleteIntegrationInstanceInput) GetId() string { return v.Id }
 
+// __FindIntegrationDefinitionInput is used internally by genqlient
+type __FindIntegrationDefinitionInput struct {
+	IntegrationType string `json:"integrationType"`
+}
+
+// GetIntegrationType returns __FindIntegrationDefinitionInput.IntegrationType, and is useful for accessing the field via an interface.
+func (v *__FindIntegrationDefinitionInput) GetIntegrationType() string { return v.IntegrationType }
+
+// __GetIntegrationDefinitionConfigFieldsInput is used internally by genqlient
+type __GetIntegrationDefinitionConfigFieldsInput struct {
+	Id string `json:"id"`
+}
+
+// GetId returns __GetIntegrationDefinitionConfigFieldsInput.Id, and is useful for accessing the field via an interface.
+func (v *__GetIntegrationDefinitionConfigFieldsInput) GetId() string { return v.Id }
+
 // __GetIntegrationDefinitionInput is used internally by genqlient
 type __GetIntegrationDefinitionInput struct {
 	Id string `json:"id"`
@@ -1533,6 +2046,18 @@ func (v *__GetIntegrationInstanceInput) GetIntegrationInstanceId() string {
 	return v.IntegrationInstanceId
 }
 
+// __GetIntegrationJobInput is used internally by genqlient
+type __GetIntegrationJobInput struct {
+	Id                    string `json:"id"`
+	IntegrationInstanceId string `json:"integrationInstanceId"`
+}
+
+// GetId returns __GetIntegrationJobInput.Id, and is useful for accessing the field via an interface.
+func (v *__GetIntegrationJobInput) GetId() string { return v.Id }
+
+// GetIntegrationInstanceId returns __GetIntegrationJobInput.IntegrationInstanceId, and is useful for accessing the field via an interface.
+func (v *__GetIntegrationJobInput) GetIntegrationInstanceId() string { return v.IntegrationInstanceId }
+
 // __IntegrationDefinitionsInput is used internally by genqlient
 type __IntegrationDefinitionsInput struct {
 	Cursor string `json:"cursor"`
@@ -1541,6 +2066,26 @@ type __IntegrationDefinitionsInput struct {
 // GetCursor returns __IntegrationDefinitionsInput.Cursor, and is useful for accessing the field via an interface.
 func (v *__IntegrationDefinitionsInput) GetCursor() string { return v.Cursor }
 
+// __IntegrationInstancesHealthInput is used internally by genqlient
+type __IntegrationInstancesHealthInput struct {
+	DefinitionId string `json:"definitionId,omitempty"`
+	Cursor       string `json:"cursor"`
+}
+
+// GetDefinitionId returns __IntegrationInstancesHealthInput.DefinitionId, and is useful for accessing the field via an interface.
+func (v *__IntegrationInstancesHealthInput) GetDefinitionId() string { return v.DefinitionId }
+
+// GetCursor returns __IntegrationInstancesHealthInput.Cursor, and is useful for accessing the field via an interface.
+func (v *__IntegrationInstancesHealthInput) GetCursor() string { return v.Cursor }
+
+// __IntegrationInstancesStatusInput is used internally by genqlient
+type __IntegrationInstancesStatusInput struct {
+	DefinitionId string `json:"definitionId,omitempty"`
+}
+
+// GetDefinitionId returns __IntegrationInstancesStatusInput.DefinitionId, and is useful for accessing the field via an interface.
+func (v *__IntegrationInstancesStatusInput) GetDefinitionId() string { return v.DefinitionId }
+
 // __InvokeInstanceInput is used internally by genqlient
 type __InvokeInstanceInput struct {
 	Id string `json:"id"`
@@ -1569,6 +2114,22 @@ func (v *__ListEventsInput) GetCursor() string { return v.Cursor }
 // GetSize returns __ListEventsInput.Size, and is useful for accessing the field via an interface.
 func (v *__ListEventsInput) GetSize() int { return v.Size }
 
+// __ListIntegrationInstanceTokensInput is used internally by genqlient
+type __ListIntegrationInstanceTokensInput struct {
+	Id     string `json:"id"`
+	Cursor string `json:"cursor"`
+	Limit  int    `json:"limit,omitempty"`
+}
+
+// GetId returns __ListIntegrationInstanceTokensInput.Id, and is useful for accessing the field via an interface.
+func (v *__ListIntegrationInstanceTokensInput) GetId() string { return v.Id }
+
+// GetCursor returns __ListIntegrationInstanceTokensInput.Cursor, and is useful for accessing the field via an interface.
+func (v *__ListIntegrationInstanceTokensInput) GetCursor() string { return v.Cursor }
+
+// GetLimit returns __ListIntegrationInstanceTokensInput.Limit, and is useful for accessing the field via an interface.
+func (v *__ListIntegrationInstanceTokensInput) GetLimit() int { return v.Limit }
+
 // __ListIntegrationInstancesInput is used internally by genqlient
 type __ListIntegrationInstancesInput struct {
 	Cursor string `json:"cursor"`
@@ -1577,6 +2138,34 @@ type __ListIntegrationInstancesInput struct {
 // GetCursor returns __ListIntegrationInstancesInput.Cursor, and is useful for accessing the field via an interface.
 func (v *__ListIntegrationInstancesInput) GetCursor() string { return v.Cursor }
 
+// __ListJobsAcrossInstancesInput is used internally by genqlient
+type __ListJobsAcrossInstancesInput struct {
+	Status                  IntegrationJobStatus `json:"status,omitempty"`
+	IntegrationInstanceIds  []string             `json:"integrationInstanceIds,omitempty"`
+	IntegrationDefinitionId string               `json:"integrationDefinitionId,omitempty"`
+	Cursor                  string               `json:"cursor"`
+	Size                    int                  `json:"size"`
+}
+
+// GetStatus returns __ListJobsAcrossInstancesInput.Status, and is useful for accessing the field via an interface.
+func (v *__ListJobsAcrossInstancesInput) GetStatus() IntegrationJobStatus { return v.Status }
+
+// GetIntegrationInstanceIds returns __ListJobsAcrossInstancesInput.IntegrationInstanceIds, and is useful for accessing the field via an interface.
+func (v *__ListJobsAcrossInstancesInput) GetIntegrationInstanceIds() []string {
+	return v.IntegrationInstanceIds
+}
+
+// GetIntegrationDefinitionId returns __ListJobsAcrossInstancesInput.IntegrationDefinitionId, and is useful for accessing the field via an interface.
+func (v *__ListJobsAcrossInstancesInput) GetIntegrationDefinitionId() string {
+	return v.IntegrationDefinitionId
+}
+
+// GetCursor returns __ListJobsAcrossInstancesInput.Cursor, and is useful for accessing the field via an interface.
+func (v *__ListJobsAcrossInstancesInput) GetCursor() string { return v.Cursor }
+
+// GetSize returns __ListJobsAcrossInstancesInput.Size, and is useful for accessing the field via an interface.
+func (v *__ListJobsAcrossInstancesInput) GetSize() int { return v.Size }
+
 // __ListJobsInput is used internally by genqlient
 type __ListJobsInput struct {
 	IntegrationInstanceId string `json:"integrationInstanceId"`
@@ -1671,6 +2260,32 @@ func (v *__QueryJupiterOneInput) GetRemember() bool { return v.Remember }
 // GetVariables returns __QueryJupiterOneInput.Variables, and is useful for accessing the field via an interface.
 func (v *__QueryJupiterOneInput) GetVariables() map[string]interface{} { return v.Variables }
 
+// __RevokeIntegrationInstanceTokenInput is used internally by genqlient
+type __RevokeIntegrationInstanceTokenInput struct {
+	TokenId string `json:"tokenId"`
+}
+
+// GetTokenId returns __RevokeIntegrationInstanceTokenInput.TokenId, and is useful for accessing the field via an interface.
+func (v *__RevokeIntegrationInstanceTokenInput) GetTokenId() string { return v.TokenId }
+
+// __SearchIntegrationInstancesInput is used internally by genqlient
+type __SearchIntegrationInstancesInput struct {
+	DefinitionId string                               `json:"definitionId,omitempty"`
+	Cursor       string                               `json:"cursor"`
+	Filter       ListIntegrationInstancesSearchFilter `json:"filter"`
+}
+
+// GetDefinitionId returns __SearchIntegrationInstancesInput.DefinitionId, and is useful for accessing the field via an interface.
+func (v *__SearchIntegrationInstancesInput) GetDefinitionId() string { return v.DefinitionId }
+
+// GetCursor returns __SearchIntegrationInstancesInput.Cursor, and is useful for accessing the field via an interface.
+func (v *__SearchIntegrationInstancesInput) GetCursor() string { return v.Cursor }
+
+// GetFilter returns __SearchIntegrationInstancesInput.Filter, and is useful for accessing the field via an interface.
+func (v *__SearchIntegrationInstancesInput) GetFilter() ListIntegrationInstancesSearchFilter {
+	return v.Filter
+}
+
 // __UpdateIntegrationInstanceInput is used internally by genqlient
 type __UpdateIntegrationInstanceInput struct {
 	Id     string                         `json:"id"`
@@ -1701,10 +2316,76 @@ func (v *__VertexInput) GetId() string { return v.Id }
 // GetPropertyFilters returns __VertexInput.PropertyFilters, and is useful for accessing the field via an interface.
 func (v *__VertexInput) GetPropertyFilters() map[string]interface{} { return v.PropertyFilters }
 
-func CreateInstance(
+func BulkUpdateIntegrationInstances(
 	ctx context.Context,
 	client graphql.Client,
-	instance CreateIntegrationInstanceInput,
+	ids []string,
+	update UpdateIntegrationInstanceInput,
+) (*BulkUpdateIntegrationInstancesResponse, error) {
+	req := &graphql.Request{
+		OpName: "BulkUpdateIntegrationInstances",
+		Query: `
+mutation BulkUpdateIntegrationInstances ($ids: [String!]!, $update: UpdateIntegrationInstanceInput!) {
+	bulkUpdateIntegrationInstances(ids: $ids, update: $update) {
+		success
+		failed
+		failedIds
+	}
+}
+`,
+		Variables: &__BulkUpdateIntegrationInstancesInput{
+			Ids:    ids,
+			Update: update,
+		},
+	}
+	var err error
+
+	var data BulkUpdateIntegrationInstancesResponse
+	resp := &graphql.Response{Data: &data}
+
+	err = client.MakeRequest(
+		ctx,
+		req,
+		resp,
+	)
+
+	return &data, err
+}
+
+func CountIntegrationInstances(
+	ctx context.Context,
+	client graphql.Client,
+	definitionId string,
+) (*CountIntegrationInstancesResponse, error) {
+	req := &graphql.Request{
+		OpName: "CountIntegrationInstances",
+		Query: `
+query CountIntegrationInstances ($definitionId: String) {
+	integrationInstanceCount(definitionId: $definitionId)
+}
+`,
+		Variables: &__CountIntegrationInstancesInput{
+			DefinitionId: definitionId,
+		},
+	}
+	var err error
+
+	var data CountIntegrationInstancesResponse
+	resp := &graphql.Response{Data: &data}
+
+	err = client.MakeRequest(
+		ctx,
+		req,
+		resp,
+	)
+
+	return &data, err
+}
+
+func CreateInstance(
+	ctx context.Context,
+	client graphql.Client,
+	instance CreateIntegrationInstanceInput,
 ) (*CreateInstanceResponse, error) {
 	req := &graphql.Request{
 		OpName: "CreateInstance",
@@ -1739,6 +2420,41 @@ mutation CreateInstance ($instance: CreateIntegrationInstanceInput!) {
 	return &data, err
 }
 
+func CreateIntegrationInstanceToken(
+	ctx context.Context,
+	client graphql.Client,
+	id string,
+) (*CreateIntegrationInstanceTokenResponse, error) {
+	req := &graphql.Request{
+		OpName: "CreateIntegrationInstanceToken",
+		Query: `
+mutation CreateIntegrationInstanceToken ($id: String!) {
+	createIntegrationInstanceToken(id: $id) {
+		tokenId
+		token
+		createdAt
+		expiresAt
+	}
+}
+`,
+		Variables: &__CreateIntegrationInstanceTokenInput{
+			Id: id,
+		},
+	}
+	var err error
+
+	var data CreateIntegrationInstanceTokenResponse
+	resp := &graphql.Response{Data: &data}
+
+	err = client.MakeRequest(
+		ctx,
+		req,
+		resp,
+	)
+
+	return &data, err
+}
+
 func DeleteIntegrationInstance(
 	ctx context.Context,
 	client graphql.Client,
@@ -1771,6 +2487,47 @@ mutation DeleteIntegrationInstance ($id: String!) {
 	return &data, err
 }
 
+func FindIntegrationDefinition(
+	ctx context.Context,
+	client graphql.Client,
+	integrationType string,
+) (*FindIntegrationDefinitionResponse, error) {
+	req := &graphql.Request{
+		OpName: "FindIntegrationDefinition",
+		Query: `
+query FindIntegrationDefinition ($integrationType: String!) {
+	findIntegrationDefinition(integrationType: $integrationType) {
+		id
+		integrationType
+		integrationClass
+		name
+		repoWebLink
+		title
+		configFields {
+			key
+			mask
+		}
+	}
+}
+`,
+		Variables: &__FindIntegrationDefinitionInput{
+			IntegrationType: integrationType,
+		},
+	}
+	var err error
+
+	var data FindIntegrationDefinitionResponse
+	resp := &graphql.Response{Data: &data}
+
+	err = client.MakeRequest(
+		ctx,
+		req,
+		resp,
+	)
+
+	return &data, err
+}
+
 func GetIntegrationDefinition(
 	ctx context.Context,
 	client graphql.Client,
@@ -1787,6 +2544,10 @@ query GetIntegrationDefinition ($id: String) {
 		name
 		title
 		repoWebLink
+		configFields {
+			key
+			mask
+		}
 	}
 }
 `,
@@ -1808,6 +2569,75 @@ query GetIntegrationDefinition ($id: String) {
 	return &data, err
 }
 
+func GetIntegrationDefinitionConfigFields(
+	ctx context.Context,
+	client graphql.Client,
+	id string,
+) (*GetIntegrationDefinitionConfigFieldsResponse, error) {
+	req := &graphql.Request{
+		OpName: "GetIntegrationDefinitionConfigFields",
+		Query: `
+query GetIntegrationDefinitionConfigFields ($id: String) {
+	integrationDefinition(id: $id) {
+		id
+		integrationType
+		configFields {
+			key
+			displayName
+			description
+			type
+			format
+			defaultValue
+			mask
+			optional
+			immutable
+			readonly
+			computed
+			options {
+				value
+				label
+				default
+			}
+			configFields {
+				key
+				displayName
+				description
+				type
+				format
+				defaultValue
+				mask
+				optional
+				immutable
+				readonly
+				computed
+				options {
+					value
+					label
+					default
+				}
+			}
+		}
+	}
+}
+`,
+		Variables: &__GetIntegrationDefinitionConfigFieldsInput{
+			Id: id,
+		},
+	}
+	var err error
+
+	var data GetIntegrationDefinitionConfigFieldsResponse
+	resp := &graphql.Response{Data: &data}
+
+	err = client.MakeRequest(
+		ctx,
+		req,
+		resp,
+	)
+
+	return &data, err
+}
+
 func GetIntegrationInstance(
 	ctx context.Context,
 	client graphql.Client,
@@ -1880,6 +2710,45 @@ fragment IntegrationInstanceJobValues on IntegrationJob {
 	return &data, err
 }
 
+func GetIntegrationJob(
+	ctx context.Context,
+	client graphql.Client,
+	id string,
+	integrationInstanceId string,
+) (*GetIntegrationJobResponse, error) {
+	req := &graphql.Request{
+		OpName: "GetIntegrationJob",
+		Query: `
+query GetIntegrationJob ($id: ID!, $integrationInstanceId: String!) {
+	integrationJob(id: $id, integrationInstanceId: $integrationInstanceId) {
+		id
+		createDate
+		endDate
+		errorsOccurred
+		status
+		integrationInstanceId
+	}
+}
+`,
+		Variables: &__GetIntegrationJobInput{
+			Id:                    id,
+			IntegrationInstanceId: integrationInstanceId,
+		},
+	}
+	var err error
+
+	var data GetIntegrationJobResponse
+	resp := &graphql.Response{Data: &data}
+
+	err = client.MakeRequest(
+		ctx,
+		req,
+		resp,
+	)
+
+	return &data, err
+}
+
 func IntegrationDefinitions(
 	ctx context.Context,
 	client graphql.Client,
@@ -1899,6 +2768,7 @@ query IntegrationDefinitions ($cursor: String) {
 			title
 			configFields {
 				key
+				mask
 			}
 		}
 		pageInfo {
@@ -1926,6 +2796,101 @@ query IntegrationDefinitions ($cursor: String) {
 	return &data, err
 }
 
+func IntegrationInstancesHealth(
+	ctx context.Context,
+	client graphql.Client,
+	definitionId string,
+	cursor string,
+) (*IntegrationInstancesHealthResponse, error) {
+	req := &graphql.Request{
+		OpName: "IntegrationInstancesHealth",
+		Query: `
+query IntegrationInstancesHealth ($definitionId: String, $cursor: String) {
+	integrationInstances(definitionId: $definitionId, cursor: $cursor) {
+		instances {
+			id
+			name
+			integrationDefinitionId
+			lastJob: jobs(size: 1) {
+				jobs {
+					id
+					createDate
+					endDate
+					errorsOccurred
+					status
+					integrationInstanceId
+				}
+			}
+			lastSuccessfulJob: jobs(status: COMPLETED, size: 1) {
+				jobs {
+					id
+					createDate
+					endDate
+					errorsOccurred
+					status
+					integrationInstanceId
+				}
+			}
+		}
+		pageInfo {
+			endCursor
+			hasNextPage
+		}
+	}
+}
+`,
+		Variables: &__IntegrationInstancesHealthInput{
+			DefinitionId: definitionId,
+			Cursor:       cursor,
+		},
+	}
+	var err error
+
+	var data IntegrationInstancesHealthResponse
+	resp := &graphql.Response{Data: &data}
+
+	err = client.MakeRequest(
+		ctx,
+		req,
+		resp,
+	)
+
+	return &data, err
+}
+
+func IntegrationInstancesStatus(
+	ctx context.Context,
+	client graphql.Client,
+	definitionId string,
+) (*IntegrationInstancesStatusResponse, error) {
+	req := &graphql.Request{
+		OpName: "IntegrationInstancesStatus",
+		Query: `
+query IntegrationInstancesStatus ($definitionId: String) {
+	integrationInstancesStatus(definitionId: $definitionId) {
+		status
+		statusesByDefinition
+	}
+}
+`,
+		Variables: &__IntegrationInstancesStatusInput{
+			DefinitionId: definitionId,
+		},
+	}
+	var err error
+
+	var data IntegrationInstancesStatusResponse
+	resp := &graphql.Response{Data: &data}
+
+	err = client.MakeRequest(
+		ctx,
+		req,
+		resp,
+	)
+
+	return &data, err
+}
+
 func InvokeInstance(
 	ctx context.Context,
 	client graphql.Client,
@@ -2007,6 +2972,51 @@ query ListEvents ($jobId: String!, $integrationInstanceId: String!, $cursor: Str
 	return &data, err
 }
 
+func ListIntegrationInstanceTokens(
+	ctx context.Context,
+	client graphql.Client,
+	id string,
+	cursor string,
+	limit int,
+) (*ListIntegrationInstanceTokensResponse, error) {
+	req := &graphql.Request{
+		OpName: "ListIntegrationInstanceTokens",
+		Query: `
+query ListIntegrationInstanceTokens ($id: String!, $cursor: String, $limit: Int) {
+	integrationInstanceTokens(id: $id, cursor: $cursor, limit: $limit) {
+		tokens {
+			tokenId
+			token
+			createdAt
+			expiresAt
+		}
+		pageInfo {
+			endCursor
+			hasNextPage
+		}
+	}
+}
+`,
+		Variables: &__ListIntegrationInstanceTokensInput{
+			Id:     id,
+			Cursor: cursor,
+			Limit:  limit,
+		},
+	}
+	var err error
+
+	var data ListIntegrationInstanceTokensResponse
+	resp := &graphql.Response{Data: &data}
+
+	err = client.MakeRequest(
+		ctx,
+		req,
+		resp,
+	)
+
+	return &data, err
+}
+
 func ListIntegrationInstances(
 	ctx context.Context,
 	client graphql.Client,
@@ -2022,6 +3032,8 @@ query ListIntegrationInstances ($cursor: String) {
 			name
 			description
 			sourceIntegrationInstanceId
+			integrationDefinitionId
+			config
 			pollingInterval
 			pollingIntervalCronExpression {
 				hour
@@ -2108,6 +3120,57 @@ query ListJobs ($integrationInstanceId: String!, $cursor: String, $size: Int) {
 	return &data, err
 }
 
+func ListJobsAcrossInstances(
+	ctx context.Context,
+	client graphql.Client,
+	status IntegrationJobStatus,
+	integrationInstanceIds []string,
+	integrationDefinitionId string,
+	cursor string,
+	size int,
+) (*ListJobsAcrossInstancesResponse, error) {
+	req := &graphql.Request{
+		OpName: "ListJobsAcrossInstances",
+		Query: `
+query ListJobsAcrossInstances ($status: IntegrationJobStatus, $integrationInstanceIds: [String], $integrationDefinitionId: String, $cursor: String, $size: Int) {
+	integrationJobs(status: $status, integrationInstanceIds: $integrationInstanceIds, integrationDefinitionId: $integrationDefinitionId, cursor: $cursor, size: $size) {
+		jobs {
+			id
+			createDate
+			endDate
+			errorsOccurred
+			status
+			integrationInstanceId
+		}
+		pageInfo {
+			endCursor
+			hasNextPage
+		}
+	}
+}
+`,
+		Variables: &__ListJobsAcrossInstancesInput{
+			Status:                  status,
+			IntegrationInstanceIds:  integrationInstanceIds,
+			IntegrationDefinitionId: integrationDefinitionId,
+			Cursor:                  cursor,
+			Size:                    size,
+		},
+	}
+	var err error
+
+	var data ListJobsAcrossInstancesResponse
+	resp := &graphql.Response{Data: &data}
+
+	err = client.MakeRequest(
+		ctx,
+		req,
+		resp,
+	)
+
+	return &data, err
+}
+
 func ListVerticesV2(
 	ctx context.Context,
 	client graphql.Client,
@@ -2221,6 +3284,98 @@ query QueryJupiterOne ($query: String!, $cursor: String, $deferredFormat: Deferr
 	return &data, err
 }
 
+func RevokeIntegrationInstanceToken(
+	ctx context.Context,
+	client graphql.Client,
+	tokenId string,
+) (*RevokeIntegrationInstanceTokenResponse, error) {
+	req := &graphql.Request{
+		OpName: "RevokeIntegrationInstanceToken",
+		Query: `
+mutation RevokeIntegrationInstanceToken ($tokenId: String!) {
+	revokeIntegrationInstanceToken(tokenId: $tokenId) {
+		success
+	}
+}
+`,
+		Variables: &__RevokeIntegrationInstanceTokenInput{
+			TokenId: tokenId,
+		},
+	}
+	var err error
+
+	var data RevokeIntegrationInstanceTokenResponse
+	resp := &graphql.Response{Data: &data}
+
+	err = client.MakeRequest(
+		ctx,
+		req,
+		resp,
+	)
+
+	return &data, err
+}
+
+func SearchIntegrationInstances(
+	ctx context.Context,
+	client graphql.Client,
+	definitionId string,
+	cursor string,
+	filter ListIntegrationInstancesSearchFilter,
+) (*SearchIntegrationInstancesResponse, error) {
+	req := &graphql.Request{
+		OpName: "SearchIntegrationInstances",
+		Query: `
+query SearchIntegrationInstances ($definitionId: String, $cursor: String, $filter: ListIntegrationInstancesSearchFilter) {
+	integrationInstances(definitionId: $definitionId, cursor: $cursor, filter: $filter) {
+		instances {
+			id
+			name
+			description
+			sourceIntegrationInstanceId
+			integrationDefinitionId
+			config
+			pollingInterval
+			pollingIntervalCronExpression {
+				hour
+				dayOfWeek
+				__typename
+			}
+			integrationDefinition {
+				id
+				integrationType
+				integrationClass
+				name
+				title
+			}
+		}
+		pageInfo {
+			endCursor
+			hasNextPage
+		}
+	}
+}
+`,
+		Variables: &__SearchIntegrationInstancesInput{
+			DefinitionId: definitionId,
+			Cursor:       cursor,
+			Filter:       filter,
+		},
+	}
+	var err error
+
+	var data SearchIntegrationInstancesResponse
+	resp := &graphql.Response{Data: &data}
+
+	err = client.MakeRequest(
+		ctx,
+		req,
+		resp,
+	)
+
+	return &data, err
+}
+
 func UpdateIntegrationInstance(
 	ctx context.Context,
 	client graphql.Client,
@@ -0,0 +1,261 @@
+package jupiterone
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jupiterone/jupiterone-client-go/jupiterone/graphql"
+)
+
+var ErrInvalidInstanceConfig = errors.New("invalid integration instance config")
+
+// InstanceConfigError lists every problem found by ValidateInstanceConfig.
+// It matches ErrInvalidInstanceConfig with errors.Is.
+type InstanceConfigError struct {
+	Problems []ConfigFieldProblem
+}
+
+// ConfigFieldProblem is a single invalid config field. Nested fields are
+// reported with a dotted key, such as "auth.clientId".
+type ConfigFieldProblem struct {
+	Key     string
+	Message string
+}
+
+func (e *InstanceConfigError) Error() string {
+	problems := make([]string, 0, len(e.Problems))
+	for _, p := range e.Problems {
+		problems = append(problems, p.Key+" "+p.Message)
+	}
+	return fmt.Sprintf("%s: %s", ErrInvalidInstanceConfig, strings.Join(problems, "; "))
+}
+
+func (e *InstanceConfigError) Is(target error) bool {
+	return target == ErrInvalidInstanceConfig
+}
+
+// ConfigValidationOptions configures ValidateInstanceConfig. The zero value applies every check.
+type ConfigValidationOptions struct {
+	// AllowUnknownKeys accepts keys that are not config fields of the definition, for
+	// definitions that also take extra or legacy keys. Known fields are still checked.
+	AllowUnknownKeys bool
+}
+
+// GetDefinitionConfigFields returns the config field specification published by the
+// integration definition with the given id.
+func (s *IntegrationService) GetDefinitionConfigFields(definitionID string) ([]graphql.ConfigFieldSpec, error) {
+	resp, err := graphql.GetIntegrationDefinitionConfigFields(context.Background(), s.client.gqlClient, definitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.IntegrationDefinition.Id == "" {
+		return nil, ErrIntegrationDefinitionNotFound
+	}
+
+	return resp.IntegrationDefinition.ConfigFields, nil
+}
+
+// ValidateConfig converts config to an instance config map with MarshalInstanceConfig and
+// checks it against the config fields of the definition with the given id.
+func (s *IntegrationService) ValidateConfig(definitionID string, config interface{}, opts ConfigValidationOptions) (map[string]interface{}, error) {
+	fields, err := s.GetDefinitionConfigFields(definitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := MarshalInstanceConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return values, ValidateInstanceConfig(fields, values, opts)
+}
+
+// CreateValidatedInstance validates config against the instance's integration definition
+// and creates the instance with it. config may be a map or a struct with json tags. The
+// instance is not created if the config is invalid; the error is then an *InstanceConfigError.
+func (s *IntegrationService) CreateValidatedInstance(instance graphql.CreateIntegrationInstanceInput, config interface{}, opts ConfigValidationOptions) (*graphql.CreateInstanceResponse, error) {
+	values, err := s.ValidateConfig(instance.IntegrationDefinitionId, config, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	instance.Config = &values
+	return s.CreateInstance(instance)
+}
+
+// MarshalInstanceConfig converts a struct with json tags into the map used as
+// integration instance config. Maps are passed through JSON as well so that their
+// values have the same types as config read back from the API.
+func MarshalInstanceConfig(config interface{}) (map[string]interface{}, error) {
+	b, err := json.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+
+	var values map[string]interface{}
+	if err := json.Unmarshal(b, &values); err != nil {
+		return nil, fmt.Errorf("%w: config must be an object: %v", ErrInvalidInstanceConfig, err)
+	}
+
+	return values, nil
+}
+
+// ValidateInstanceConfig checks config against the fields of an integration definition:
+//   - fields that are not optional and have no default value must be set
+//   - values must match the field type and, if the field has options, be one of them
+//   - read-only and computed fields must not be set
+//   - masked fields must not hold the placeholder the API returns instead of their value
+//   - keys that are not config fields of the definition are rejected, unless
+//     opts.AllowUnknownKeys is set
+//
+// All problems are reported together in an *InstanceConfigError.
+func ValidateInstanceConfig(fields []graphql.ConfigFieldSpec, config map[string]interface{}, opts ConfigValidationOptions) error {
+	var problems []ConfigFieldProblem
+
+	known := map[string]bool{}
+	for _, field := range fields {
+		known[field.Key] = true
+		problems = append(problems, validateConfigField(toConfigFieldSpec(field), field.Key, config)...)
+
+		if nested, ok := config[field.Key].(map[string]interface{}); ok && len(field.ConfigFields) > 0 {
+			problems = append(problems, validateNestedConfig(field, nested, opts)...)
+		}
+	}
+
+	if !opts.AllowUnknownKeys {
+		problems = append(problems, unknownConfigKeys("", known, config)...)
+	}
+
+	if len(problems) > 0 {
+		return &InstanceConfigError{Problems: problems}
+	}
+	return nil
+}
+
+func validateNestedConfig(parent graphql.ConfigFieldSpec, config map[string]interface{}, opts ConfigValidationOptions) []ConfigFieldProblem {
+	var problems []ConfigFieldProblem
+
+	known := map[string]bool{}
+	for _, field := range parent.ConfigFields {
+		known[field.Key] = true
+		problems = append(problems, validateConfigField(nestedToConfigFieldSpec(field), parent.Key+"."+field.Key, config)...)
+	}
+
+	if opts.AllowUnknownKeys {
+		return problems
+	}
+	return append(problems, unknownConfigKeys(parent.Key+".", known, config)...)
+}
+
+// configFieldSpec is a ConfigFieldSpec without its nested fields, so that top-level
+// and nested fields are validated by the same code.
+type configFieldSpec struct {
+	key          string
+	fieldType    string
+	defaultValue json.RawMessage
+	mask         bool
+	optional     bool
+	readonly     bool
+	computed     bool
+	options      []graphql.ConfigFieldOption
+}
+
+func toConfigFieldSpec(f graphql.ConfigFieldSpec) configFieldSpec {
+	return configFieldSpec{f.Key, f.Type, f.DefaultValue, f.Mask, f.Optional, f.Readonly, f.Computed, f.Options}
+}
+
+func nestedToConfigFieldSpec(f graphql.NestedConfigFieldSpec) configFieldSpec {
+	return configFieldSpec{f.Key, f.Type, f.DefaultValue, f.Mask, f.Optional, f.Readonly, f.Computed, f.Options}
+}
+
+func validateConfigField(field configFieldSpec, name string, config map[string]interface{}) []ConfigFieldProblem {
+	problem := func(format string, args ...interface{}) []ConfigFieldProblem {
+		return []ConfigFieldProblem{{Key: name, Message: fmt.Sprintf(format, args...)}}
+	}
+
+	value, ok := config[field.key]
+	if !ok || value == nil || value == "" {
+		hasDefault := len(field.defaultValue) > 0 && string(field.defaultValue) != "null"
+		if !field.optional && !field.readonly && !field.computed && !hasDefault {
+			return problem("is required")
+		}
+		return nil
+	}
+
+	if field.readonly || field.computed {
+		return problem("is read-only")
+	}
+
+	if s, ok := value.(string); ok && field.mask && strings.Trim(s, "*") == "" {
+		return problem("holds a masked placeholder instead of a value")
+	}
+
+	if expected, ok := configValueMatchesType(field.fieldType, value); !ok {
+		return problem("must be a %s, got %T", expected, value)
+	}
+
+	if len(field.options) > 0 {
+		s, _ := value.(string)
+		for _, option := range field.options {
+			if option.Value == s {
+				return nil
+			}
+		}
+		return problem("must be one of %s", configOptionValues(field.options))
+	}
+
+	return nil
+}
+
+// configValueMatchesType checks a JSON-decoded value against a config field type.
+// Types the client does not know about are not checked.
+func configValueMatchesType(fieldType string, value interface{}) (string, bool) {
+	switch strings.ToLower(fieldType) {
+	case "string", "text":
+		_, ok := value.(string)
+		return "string", ok
+	case "boolean":
+		_, ok := value.(bool)
+		return "boolean", ok
+	case "number":
+		_, ok := value.(float64)
+		return "number", ok
+	case "integer":
+		n, ok := value.(float64)
+		return "integer", ok && n == float64(int64(n))
+	case "object":
+		_, ok := value.(map[string]interface{})
+		return "object", ok
+	case "array":
+		_, ok := value.([]interface{})
+		return "array", ok
+	default:
+		return fieldType, true
+	}
+}
+
+func configOptionValues(options []graphql.ConfigFieldOption) string {
+	values := make([]string, 0, len(options))
+	for _, option := range options {
+		values = append(values, fmt.Sprintf("%q", option.Value))
+	}
+	return strings.Join(values, ", ")
+}
+
+func unknownConfigKeys(prefix string, known map[string]bool, config map[string]interface{}) []ConfigFieldProblem {
+	var problems []ConfigFieldProblem
+	for key := range config {
+		if !known[key] {
+			problems = append(problems, ConfigFieldProblem{Key: prefix + key, Message: "is not a config field of this definition"})
+		}
+	}
+
+	sort.Slice(problems, func(i, j int) bool { return problems[i].Key < problems[j].Key })
+	return problems
+}
@@ -0,0 +1,429 @@
+package jupiterone
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/jupiterone/jupiterone-client-go/jupiterone/graphql"
+)
+
+var ErrInvokeFailed = errors.New("integration instance invocation was not successful")
+
+const defaultJobPollInterval = 10 * time.Second
+
+// InvokeAndWaitOptions configures InvokeAndWait. The zero value uses the defaults.
+// Use a context deadline to bound how long InvokeAndWait waits.
+type InvokeAndWaitOptions struct {
+	// PollInterval is how often the job status is checked. Defaults to 10 seconds.
+	PollInterval time.Duration
+}
+
+// IntegrationJobResult is the final state of an integration job together
+// with the warning and error events it produced.
+type IntegrationJobResult struct {
+	Job graphql.IntegrationJob
+	// Integration events have no severity, so Warnings and Errors are guessed from the
+	// event names. Errors holds the events whose name has the word "error", "errors",
+	// "fail", "failed", "failure" or "failures", and Warnings those with "warn",
+	// "warning" or "warnings", where words are separated by anything other than a letter
+	// or digit. An event named "step_failure" is an error, while "failover_configured"
+	// or "ErrorBudgetChecked" is neither. Events can be misclassified either way.
+	Warnings []graphql.IntegrationEvent
+	Errors   []graphql.IntegrationEvent
+}
+
+// Failed reports whether the job ended in the FAILED status.
+func (r *IntegrationJobResult) Failed() bool {
+	return r.Job.Status == graphql.IntegrationJobStatusFailed
+}
+
+// InvokeAndWait invokes the integration instance with id instanceID and polls the
+// resulting job until it is COMPLETED or FAILED.
+//
+// The job is the one reported by the invocation. If the API does not report a job id,
+// the instance's jobs are listed before the invocation and the first job that was not in
+// that list is used instead. A job started by someone else at the same moment cannot be
+// told apart from the invoked one in that case.
+//
+// Once an invocation of an instance has reported a job id, the Client skips listing the
+// instance's jobs before later invocations of it. Should such an invocation not report a
+// job id after all, the newest job of the instance is used.
+//
+// A job that ends in FAILED is not an error; check IntegrationJobResult.Failed and
+// IntegrationJobResult.Errors.
+func (s *IntegrationService) InvokeAndWait(ctx context.Context, instanceID string, opts InvokeAndWaitOptions) (*IntegrationJobResult, error) {
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = defaultJobPollInterval
+	}
+
+	_, reportsJobID := s.client.jobIDReportingInstances.Load(instanceID)
+
+	var existing map[string]bool
+	if !reportsJobID {
+		var err error
+		if existing, err = s.latestJobIDs(ctx, instanceID); err != nil {
+			return nil, err
+		}
+	}
+
+	invocation, err := graphql.InvokeInstance(ctx, s.client.gqlClient, instanceID)
+	if err != nil {
+		return nil, err
+	}
+
+	jobID := invocation.InvokeIntegrationInstance.IntegrationJobId
+	switch {
+	case jobID != "":
+		s.client.jobIDReportingInstances.Store(instanceID, true)
+	case !invocation.InvokeIntegrationInstance.Success:
+		return nil, ErrInvokeFailed
+	case reportsJobID:
+		// There is no earlier list of jobs to compare with, so the newest job is used.
+		s.client.jobIDReportingInstances.Delete(instanceID)
+		if jobID, err = s.waitForNewJob(ctx, instanceID, nil, opts.PollInterval); err != nil {
+			return nil, err
+		}
+	default:
+		if jobID, err = s.waitForNewJob(ctx, instanceID, existing, opts.PollInterval); err != nil {
+			return nil, err
+		}
+	}
+
+	job, err := s.waitForJob(ctx, instanceID, jobID, opts.PollInterval)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := s.JobEventsIterator(ctx, instanceID, jobID, 0).All()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &IntegrationJobResult{Job: *job}
+	for _, event := range events {
+		switch {
+		case isErrorEvent(event):
+			result.Errors = append(result.Errors, event)
+		case isWarningEvent(event):
+			result.Warnings = append(result.Warnings, event)
+		}
+	}
+
+	return result, nil
+}
+
+// latestJobIDs returns the ids of the first page of the instance's jobs, which the API
+// lists newest first.
+func (s *IntegrationService) latestJobIDs(ctx context.Context, instanceID string) (map[string]bool, error) {
+	resp, err := graphql.ListJobs(ctx, s.client.gqlClient, instanceID, "", 0)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make(map[string]bool, len(resp.IntegrationJobs.Jobs))
+	for _, job := range resp.IntegrationJobs.Jobs {
+		ids[job.Id] = true
+	}
+	return ids, nil
+}
+
+// waitForNewJob polls the instance's jobs until one whose id is not in existing appears.
+// If several appear at once, the one the server created first is returned. If existing
+// is nil, the newest job is returned as soon as there is one.
+func (s *IntegrationService) waitForNewJob(ctx context.Context, instanceID string, existing map[string]bool, interval time.Duration) (string, error) {
+	for {
+		resp, err := graphql.ListJobs(ctx, s.client.gqlClient, instanceID, "", 0)
+		if err != nil {
+			return "", err
+		}
+
+		if existing == nil && len(resp.IntegrationJobs.Jobs) > 0 {
+			return resp.IntegrationJobs.Jobs[0].Id, nil
+		}
+
+		var first *graphql.IntegrationJob
+		for i, job := range resp.IntegrationJobs.Jobs {
+			if !existing[job.Id] && (first == nil || job.CreateDate < first.CreateDate) {
+				first = &resp.IntegrationJobs.Jobs[i]
+			}
+		}
+		if first != nil {
+			return first.Id, nil
+		}
+
+		if err := sleepContext(ctx, interval); err != nil {
+			return "", err
+		}
+	}
+}
+
+// waitForJob polls a job until it reaches a terminal status.
+func (s *IntegrationService) waitForJob(ctx context.Context, instanceID string, jobID string, interval time.Duration) (*graphql.IntegrationJob, error) {
+	for {
+		resp, err := graphql.GetIntegrationJob(ctx, s.client.gqlClient, jobID, instanceID)
+		if err != nil {
+			return nil, err
+		}
+
+		if isTerminalJobStatus(resp.IntegrationJob.Status) {
+			return &resp.IntegrationJob, nil
+		}
+
+		if err := sleepContext(ctx, interval); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// TailJobEvents streams the events of an integration job as they are recorded, like tail -f.
+// The job's events are polled incrementally and each event is delivered once. Both channels
+// are closed after the job reaches a terminal status and its remaining events have been
+// delivered, or after the first error. The error channel receives at most one error,
+// including ctx.Err() when ctx is done.
+func (s *IntegrationService) TailJobEvents(ctx context.Context, instanceID string, jobID string) (<-chan graphql.IntegrationEvent, <-chan error) {
+	return s.tailJobEvents(ctx, instanceID, jobID, defaultJobPollInterval)
+}
+
+func (s *IntegrationService) tailJobEvents(ctx context.Context, instanceID string, jobID string, interval time.Duration) (<-chan graphql.IntegrationEvent, <-chan error) {
+	events := make(chan graphql.IntegrationEvent)
+	errc := make(chan error, 1)
+
+	go func() {
+		defer close(events)
+		defer close(errc)
+
+		seen := map[string]bool{}
+		cursor := ""
+
+		for {
+			// Check the status before reading events so that the last read
+			// after the job finishes picks up everything it recorded.
+			resp, err := graphql.GetIntegrationJob(ctx, s.client.gqlClient, jobID, instanceID)
+			if err != nil {
+				errc <- err
+				return
+			}
+			finished := isTerminalJobStatus(resp.IntegrationJob.Status)
+
+			cursor, err = s.sendNewJobEvents(ctx, instanceID, jobID, cursor, seen, events)
+			if err != nil {
+				errc <- err
+				return
+			}
+
+			if finished {
+				return
+			}
+
+			if err := sleepContext(ctx, interval); err != nil {
+				errc <- err
+				return
+			}
+		}
+	}()
+
+	return events, errc
+}
+
+// sendNewJobEvents reads the job's events starting at cursor and sends the ones not yet seen.
+// It returns the cursor of the last page read so the next poll can resume from there.
+func (s *IntegrationService) sendNewJobEvents(ctx context.Context, instanceID string, jobID string, cursor string, seen map[string]bool, events chan<- graphql.IntegrationEvent) (string, error) {
+	for {
+		resp, err := graphql.ListEvents(ctx, s.client.gqlClient, jobID, instanceID, cursor, 0)
+		if err != nil {
+			return cursor, err
+		}
+
+		for _, event := range resp.IntegrationEvents.Events {
+			if seen[event.Id] {
+				continue
+			}
+			seen[event.Id] = true
+
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				return cursor, ctx.Err()
+			}
+		}
+
+		pageInfo := resp.IntegrationEvents.PageInfo
+		if !pageInfo.HasNextPage || pageInfo.EndCursor == "" {
+			return cursor, nil
+		}
+		cursor = pageInfo.EndCursor
+	}
+}
+
+// IntegrationJobsFilter narrows ListJobsAcrossInstances. Zero-valued fields are not applied.
+type IntegrationJobsFilter struct {
+	Status                  graphql.IntegrationJobStatus
+	InstanceIDs             []string
+	IntegrationDefinitionID string
+	// PageSize is the number of jobs requested per page; 0 uses the default API behavior.
+	PageSize int
+}
+
+// ListJobsAcrossInstances returns an Iterator over the integration jobs of every
+// instance in the account that match filter.
+func (s *IntegrationService) ListJobsAcrossInstances(ctx context.Context, filter IntegrationJobsFilter) *Iterator[graphql.IntegrationJob] {
+	return NewIterator(ctx, func(ctx context.Context, cursor string) (Page[graphql.IntegrationJob], error) {
+		resp, err := graphql.ListJobsAcrossInstances(
+			ctx,
+			s.client.gqlClient,
+			filter.Status,
+			filter.InstanceIDs,
+			filter.IntegrationDefinitionID,
+			cursor,
+			filter.PageSize,
+		)
+		if err != nil {
+			return Page[graphql.IntegrationJob]{}, err
+		}
+
+		result := resp.IntegrationJobs
+		return Page[graphql.IntegrationJob]{
+			Items:      result.Jobs,
+			NextCursor: result.PageInfo.EndCursor,
+			HasNext:    result.PageInfo.HasNextPage,
+		}, nil
+	})
+}
+
+// IntegrationHealthSummary reports the health of every integration instance.
+type IntegrationHealthSummary struct {
+	// Status and StatusesByDefinition are passed through from integrationInstancesStatus.
+	Status               string
+	StatusesByDefinition map[string]interface{}
+	Instances            []InstanceHealth
+}
+
+// InstanceHealth is the health of a single integration instance.
+type InstanceHealth struct {
+	InstanceID              string
+	InstanceName            string
+	IntegrationDefinitionID string
+	// LastJobStatus is empty if the instance has never run.
+	LastJobStatus graphql.IntegrationJobStatus
+	LastJobTime   time.Time
+	// LastSuccessTime is when the most recent COMPLETED job ended. It is zero if
+	// the instance has never completed a job.
+	LastSuccessTime time.Time
+	// Failing is true when the most recent job FAILED or reported errors.
+	Failing bool
+}
+
+// Failing returns the instances that are currently failing.
+func (h *IntegrationHealthSummary) Failing() []InstanceHealth {
+	var failing []InstanceHealth
+	for _, instance := range h.Instances {
+		if instance.Failing {
+			failing = append(failing, instance)
+		}
+	}
+	return failing
+}
+
+// HealthSummary reports the last job status, last success time and failing state of
+// every integration instance. Pass an empty definitionID to include all definitions.
+func (s *IntegrationService) HealthSummary(ctx context.Context, definitionID string) (*IntegrationHealthSummary, error) {
+	status, err := graphql.IntegrationInstancesStatus(ctx, s.client.gqlClient, definitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &IntegrationHealthSummary{
+		Status:               status.IntegrationInstancesStatus.Status,
+		StatusesByDefinition: status.IntegrationInstancesStatus.StatusesByDefinition,
+	}
+
+	it := NewIterator(ctx, func(ctx context.Context, cursor string) (Page[graphql.IntegrationInstanceHealth], error) {
+		resp, err := graphql.IntegrationInstancesHealth(ctx, s.client.gqlClient, definitionID, cursor)
+		if err != nil {
+			return Page[graphql.IntegrationInstanceHealth]{}, err
+		}
+
+		result := resp.IntegrationInstances
+		return Page[graphql.IntegrationInstanceHealth]{
+			Items:      result.Instances,
+			NextCursor: result.PageInfo.EndCursor,
+			HasNext:    result.PageInfo.HasNextPage,
+		}, nil
+	})
+
+	for it.Next() {
+		summary.Instances = append(summary.Instances, newInstanceHealth(it.Item()))
+	}
+
+	return summary, it.Err()
+}
+
+func newInstanceHealth(instance graphql.IntegrationInstanceHealth) InstanceHealth {
+	health := InstanceHealth{
+		InstanceID:              instance.Id,
+		InstanceName:            instance.Name,
+		IntegrationDefinitionID: instance.IntegrationDefinitionId,
+	}
+
+	if jobs := instance.LastJob.Jobs; len(jobs) > 0 {
+		health.LastJobStatus = jobs[0].Status
+		health.LastJobTime = time.UnixMilli(int64(jobs[0].CreateDate))
+		health.Failing = jobs[0].Status == graphql.IntegrationJobStatusFailed || jobs[0].ErrorsOccurred
+	}
+
+	if jobs := instance.LastSuccessfulJob.Jobs; len(jobs) > 0 {
+		health.LastSuccessTime = time.UnixMilli(int64(jobs[0].EndDate))
+	}
+
+	return health
+}
+
+// isTerminalJobStatus reports whether an integration job has stopped running.
+func isTerminalJobStatus(status graphql.IntegrationJobStatus) bool {
+	return status == graphql.IntegrationJobStatusCompleted || status == graphql.IntegrationJobStatusFailed
+}
+
+// Integration events carry no severity, so it is inferred from the words of the event
+// name, which are separated by anything other than a letter or digit: "step_failure" and
+// "validation_failure" are errors and "warn" is a warning, while "failover_configured" is
+// neither.
+var (
+	errorEventWords   = map[string]bool{"error": true, "errors": true, "fail": true, "failed": true, "failure": true, "failures": true}
+	warningEventWords = map[string]bool{"warn": true, "warning": true, "warnings": true}
+)
+
+func isErrorEvent(event graphql.IntegrationEvent) bool {
+	return hasEventWord(event, errorEventWords)
+}
+
+func isWarningEvent(event graphql.IntegrationEvent) bool {
+	return hasEventWord(event, warningEventWords)
+}
+
+func hasEventWord(event graphql.IntegrationEvent, words map[string]bool) bool {
+	fields := strings.FieldsFunc(strings.ToLower(event.Name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, field := range fields {
+		if words[field] {
+			return true
+		}
+	}
+	return false
+}
+
+// sleepContext waits for d or until ctx is done, whichever happens first.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,410 @@
+package jupiterone
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jupiterone/jupiterone-client-go/jupiterone/domain"
+	"github.com/jupiterone/jupiterone-client-go/jupiterone/graphql"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	ErrInvalidDesiredInstance = errors.New("invalid desired integration instance")
+	ErrAmbiguousInstance      = errors.New("more than one integration instance has the same definition and name")
+)
+
+// maskedValue replaces the value of masked config fields in plan output.
+const maskedValue = "********"
+
+// DesiredIntegrationInstance is the declared state of a single integration instance.
+// Instances are identified by their definition and name.
+//
+// Ingestion sources are not reconciled; the GraphQL schema used by this client does
+// not expose them on integration instances.
+type DesiredIntegrationInstance struct {
+	// DefinitionType is the integration type, such as "aws". Set either
+	// DefinitionType or DefinitionID.
+	DefinitionType  string                             `json:"definitionType,omitempty" yaml:"definitionType,omitempty"`
+	DefinitionID    string                             `json:"definitionId,omitempty" yaml:"definitionId,omitempty"`
+	Name            string                             `json:"name" yaml:"name"`
+	Description     string                             `json:"description,omitempty" yaml:"description,omitempty"`
+	PollingInterval graphql.IntegrationPollingInterval `json:"pollingInterval,omitempty" yaml:"pollingInterval,omitempty"`
+	// Config is the complete instance config. Masked fields cannot be read back from
+	// the API, so they must be provided here and never trigger an update on their own.
+	Config map[string]interface{} `json:"config,omitempty" yaml:"config,omitempty"`
+}
+
+// DesiredIntegrationInstances is the document format read by ParseDesiredIntegrationInstances.
+//
+//	instances:
+//	  - definitionType: aws
+//	    name: production
+//	    pollingInterval: ONE_DAY
+//	    config:
+//	      roleArn: arn:aws:iam::123456789012:role/jupiterone
+type DesiredIntegrationInstances struct {
+	Instances []DesiredIntegrationInstance `json:"instances" yaml:"instances"`
+}
+
+// ParseDesiredIntegrationInstances parses a YAML or JSON document of desired instances.
+func ParseDesiredIntegrationInstances(data []byte) ([]DesiredIntegrationInstance, error) {
+	var doc DesiredIntegrationInstances
+
+	// YAML is a superset of JSON, so a single decoder handles both formats.
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	return doc.Instances, nil
+}
+
+// ReconcileOptions configures PlanInstances.
+type ReconcileOptions struct {
+	// Prune plans the deletion of instances that are not in the desired state.
+	// Only definitions that appear in the desired state are pruned.
+	Prune bool
+}
+
+// IntegrationInstancePlan is the set of changes needed to reach the desired state.
+type IntegrationInstancePlan struct {
+	Creates []PlannedInstanceChange
+	Updates []PlannedInstanceChange
+	Deletes []PlannedInstanceChange
+}
+
+// PlannedInstanceChange is a single create, update or delete. Values of masked
+// config fields in Changes are replaced with a placeholder.
+type PlannedInstanceChange struct {
+	InstanceID     string
+	DefinitionID   string
+	DefinitionType string
+	Name           string
+	Changes        []domain.PropertyChange
+
+	desired *DesiredIntegrationInstance
+}
+
+// IsEmpty reports whether applying the plan would change nothing.
+func (p *IntegrationInstancePlan) IsEmpty() bool {
+	return len(p.Creates) == 0 && len(p.Updates) == 0 && len(p.Deletes) == 0
+}
+
+// String renders the plan for review. Masked config values are never printed.
+func (p *IntegrationInstancePlan) String() string {
+	var b strings.Builder
+
+	write := func(symbol string, action string, change PlannedInstanceChange) {
+		fmt.Fprintf(&b, "%s %s %s/%s", symbol, action, change.DefinitionType, change.Name)
+		if change.InstanceID != "" {
+			fmt.Fprintf(&b, " (%s)", change.InstanceID)
+		}
+		b.WriteString("\n")
+
+		for _, c := range change.Changes {
+			fmt.Fprintf(&b, "    %s: %s -> %s\n", c.Name, formatPlanValue(c.Old), formatPlanValue(c.New))
+		}
+	}
+
+	for _, change := range p.Creates {
+		write("+", "create", change)
+	}
+	for _, change := range p.Updates {
+		write("~", "update", change)
+	}
+	for _, change := range p.Deletes {
+		write("-", "delete", change)
+	}
+
+	if p.IsEmpty() {
+		b.WriteString("no changes\n")
+	}
+
+	return b.String()
+}
+
+func formatPlanValue(v interface{}) string {
+	if v == nil {
+		return "(none)"
+	}
+	if s, ok := v.(string); ok && s == maskedValue {
+		return s
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
+
+// reconcileDefinition is what the reconciler needs to know about an integration definition.
+type reconcileDefinition struct {
+	id              string
+	integrationType string
+	masked          map[string]bool
+}
+
+// PlanInstances compares the desired integration instances with the instances in the
+// account, as returned by ListInstances, and returns the creates, updates and deletes
+// needed to reconcile them. Nothing is changed until the plan is passed to ApplyInstancePlan.
+func (s *IntegrationService) PlanInstances(ctx context.Context, desired []DesiredIntegrationInstance, opts ReconcileOptions) (*IntegrationInstancePlan, error) {
+	// Definition ids are filled in below; don't modify the caller's slice.
+	desired = append([]DesiredIntegrationInstance(nil), desired...)
+
+	definitions, err := s.resolveReconcileDefinitions(desired)
+	if err != nil {
+		return nil, err
+	}
+
+	existing := map[string]graphql.IntegrationInstance{}
+	it := s.InstancesIterator(ctx)
+	for it.Next() {
+		instance := it.Item()
+		if _, managed := definitions[instance.IntegrationDefinitionId]; !managed {
+			continue
+		}
+
+		key := instanceKey(instance.IntegrationDefinitionId, instance.Name)
+		if _, ok := existing[key]; ok {
+			return nil, fmt.Errorf("%w: %s", ErrAmbiguousInstance, instance.Name)
+		}
+		existing[key] = instance
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	plan := &IntegrationInstancePlan{}
+	seen := map[string]bool{}
+
+	for i := range desired {
+		instance := &desired[i]
+		definition := definitions[instance.DefinitionID]
+
+		key := instanceKey(definition.id, instance.Name)
+		if seen[key] {
+			return nil, fmt.Errorf("%w: %s is declared more than once", ErrInvalidDesiredInstance, instance.Name)
+		}
+		seen[key] = true
+
+		change := PlannedInstanceChange{
+			DefinitionID:   definition.id,
+			DefinitionType: definition.integrationType,
+			Name:           instance.Name,
+			desired:        instance,
+		}
+
+		current, ok := existing[key]
+		if !ok {
+			change.Changes = diffInstance(nil, instance, definition)
+			plan.Creates = append(plan.Creates, change)
+			continue
+		}
+
+		change.InstanceID = current.Id
+		change.Changes = diffInstance(&current, instance, definition)
+		if len(change.Changes) > 0 {
+			plan.Updates = append(plan.Updates, change)
+		}
+	}
+
+	if opts.Prune {
+		for key, current := range existing {
+			if seen[key] {
+				continue
+			}
+			plan.Deletes = append(plan.Deletes, PlannedInstanceChange{
+				InstanceID:     current.Id,
+				DefinitionID:   current.IntegrationDefinitionId,
+				DefinitionType: definitions[current.IntegrationDefinitionId].integrationType,
+				Name:           current.Name,
+			})
+		}
+		sort.Slice(plan.Deletes, func(i, j int) bool { return plan.Deletes[i].Name < plan.Deletes[j].Name })
+	}
+
+	return plan, nil
+}
+
+// ApplyInstancePlan applies the creates, updates and deletes of plan, in that order.
+// It stops at the first error.
+func (s *IntegrationService) ApplyInstancePlan(ctx context.Context, plan *IntegrationInstancePlan) error {
+	for _, change := range plan.Creates {
+		instance := change.desired
+		input := graphql.CreateIntegrationInstanceInput{
+			Name:                    instance.Name,
+			IntegrationDefinitionId: change.DefinitionID,
+		}
+		if instance.Description != "" {
+			input.Description = &instance.Description
+		}
+		if instance.PollingInterval != "" {
+			input.PollingInterval = &instance.PollingInterval
+		}
+		if instance.Config != nil {
+			input.Config = &instance.Config
+		}
+
+		if _, err := graphql.CreateInstance(ctx, s.client.gqlClient, input); err != nil {
+			return fmt.Errorf("creating %s: %w", change.Name, err)
+		}
+	}
+
+	for _, change := range plan.Updates {
+		instance := change.desired
+		input := graphql.UpdateIntegrationInstanceInput{
+			Description: &instance.Description,
+		}
+		if instance.PollingInterval != "" {
+			input.PollingInterval = &instance.PollingInterval
+		}
+		if instance.Config != nil {
+			input.Config = &instance.Config
+		}
+
+		if _, err := graphql.UpdateIntegrationInstance(ctx, s.client.gqlClient, change.InstanceID, input); err != nil {
+			return fmt.Errorf("updating %s: %w", change.Name, err)
+		}
+	}
+
+	for _, change := range plan.Deletes {
+		if _, err := graphql.DeleteIntegrationInstance(ctx, s.client.gqlClient, change.InstanceID); err != nil {
+			return fmt.Errorf("deleting %s: %w", change.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// resolveReconcileDefinitions looks up the definition of every desired instance, filling
+// in DefinitionID for instances declared by type. The result is keyed by definition id.
+func (s *IntegrationService) resolveReconcileDefinitions(desired []DesiredIntegrationInstance) (map[string]reconcileDefinition, error) {
+	definitions := map[string]reconcileDefinition{}
+	idsByType := map[string]string{}
+
+	for i := range desired {
+		instance := &desired[i]
+
+		if instance.Name == "" {
+			return nil, fmt.Errorf("%w: name is required", ErrInvalidDesiredInstance)
+		}
+
+		switch {
+		case instance.DefinitionID != "":
+		case instance.DefinitionType != "":
+			if id, ok := idsByType[instance.DefinitionType]; ok {
+				instance.DefinitionID = id
+				continue
+			}
+
+			definition, err := s.FindDefinitionByType(instance.DefinitionType)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", instance.DefinitionType, err)
+			}
+
+			instance.DefinitionID = definition.Id
+			idsByType[instance.DefinitionType] = definition.Id
+			definitions[definition.Id] = newReconcileDefinition(definition.Id, definition.IntegrationType, definition.ConfigFields)
+			continue
+		default:
+			return nil, fmt.Errorf("%w: %s needs a definitionType or definitionId", ErrInvalidDesiredInstance, instance.Name)
+		}
+
+		if _, ok := definitions[instance.DefinitionID]; ok {
+			continue
+		}
+
+		resp, err := s.GetDefinition(instance.DefinitionID)
+		if err != nil {
+			return nil, err
+		}
+		definition := resp.IntegrationDefinition
+		if definition.Id == "" {
+			return nil, fmt.Errorf("%s: %w", instance.DefinitionID, ErrIntegrationDefinitionNotFound)
+		}
+		definitions[definition.Id] = newReconcileDefinition(definition.Id, definition.IntegrationType, definition.ConfigFields)
+	}
+
+	return definitions, nil
+}
+
+func newReconcileDefinition(id string, integrationType string, fields []graphql.IntegrationDefinitionConfigFieldsConfigField) reconcileDefinition {
+	definition := reconcileDefinition{
+		id:              id,
+		integrationType: integrationType,
+		masked:          map[string]bool{},
+	}
+	for _, field := range fields {
+		if field.Mask {
+			definition.masked[field.Key] = true
+		}
+	}
+	return definition
+}
+
+// diffInstance lists the field changes between the current instance (nil when it
+// does not exist yet) and the desired one.
+func diffInstance(current *graphql.IntegrationInstance, desired *DesiredIntegrationInstance, definition reconcileDefinition) []domain.PropertyChange {
+	var changes []domain.PropertyChange
+
+	var currentDescription, currentPollingInterval interface{}
+	var currentConfig map[string]interface{}
+	if current != nil {
+		currentDescription = current.Description
+		currentPollingInterval = string(current.PollingInterval)
+		currentConfig = current.Config
+	}
+
+	if desired.Description != "" || current != nil {
+		if currentDescription != desired.Description {
+			changes = append(changes, domain.PropertyChange{Name: "description", Old: currentDescription, New: desired.Description})
+		}
+	}
+
+	if desired.PollingInterval != "" && currentPollingInterval != string(desired.PollingInterval) {
+		changes = append(changes, domain.PropertyChange{Name: "pollingInterval", Old: currentPollingInterval, New: string(desired.PollingInterval)})
+	}
+
+	for _, change := range diffProperties(currentConfig, normalizeConfig(desired.Config)) {
+		if definition.masked[change.Name] {
+			// Masked values cannot be read back, so only report them for new instances.
+			if current != nil {
+				continue
+			}
+			change.New = maskedValue
+		}
+		change.Name = "config." + change.Name
+		changes = append(changes, change)
+	}
+
+	return changes
+}
+
+// normalizeConfig round-trips config through JSON so that its values compare
+// equal to the ones decoded from the API.
+func normalizeConfig(config map[string]interface{}) map[string]interface{} {
+	if config == nil {
+		return nil
+	}
+
+	b, err := json.Marshal(config)
+	if err != nil {
+		return config
+	}
+
+	var normalized map[string]interface{}
+	if err := json.Unmarshal(b, &normalized); err != nil {
+		return config
+	}
+
+	return normalized
+}
+
+func instanceKey(definitionID string, name string) string {
+	return definitionID + "/" + name
+}
@@ -0,0 +1,75 @@
+package jupiterone
+
+import (
+	"context"
+
+	"github.com/jupiterone/jupiterone-client-go/jupiterone/graphql"
+)
+
+// CreateInstanceToken issues a new API token that authenticates as the integration
+// instance with id. The token value is only returned at creation time, so store it
+// before discarding the response.
+func (s *IntegrationService) CreateInstanceToken(id string) (*graphql.CreateIntegrationInstanceTokenResponse, error) {
+	return graphql.CreateIntegrationInstanceToken(context.Background(), s.client.gqlClient, id)
+}
+
+// ListInstanceTokens lists the tokens of the integration instance with id.
+// On the first call, pass an empty cursor ("") and the page size (0 is default api behavior).
+//
+// To paginate, the caller should check PageInfo.HasNextPage and, if true, pass PageInfo.EndCursor as
+// the cursor on the next call. InstanceTokensIterator does this for the caller.
+func (s *IntegrationService) ListInstanceTokens(id string, cursor string, limit int) (*graphql.ListIntegrationInstanceTokensResponse, error) {
+	return graphql.ListIntegrationInstanceTokens(context.Background(), s.client.gqlClient, id, cursor, limit)
+}
+
+// InstanceTokensIterator returns an Iterator over every token of the integration instance with id.
+// limit is the page size; pass 0 for the default API behavior.
+func (s *IntegrationService) InstanceTokensIterator(ctx context.Context, id string, limit int) *Iterator[graphql.IntegrationInstanceToken] {
+	return NewIterator(ctx, func(ctx context.Context, cursor string) (Page[graphql.IntegrationInstanceToken], error) {
+		resp, err := graphql.ListIntegrationInstanceTokens(ctx, s.client.gqlClient, id, cursor, limit)
+		if err != nil {
+			return Page[graphql.IntegrationInstanceToken]{}, err
+		}
+
+		result := resp.IntegrationInstanceTokens
+		return Page[graphql.IntegrationInstanceToken]{
+			Items:      result.Tokens,
+			NextCursor: result.PageInfo.EndCursor,
+			HasNext:    result.PageInfo.HasNextPage,
+		}, nil
+	})
+}
+
+// RevokeInstanceToken revokes an integration instance token by its token id.
+func (s *IntegrationService) RevokeInstanceToken(tokenID string) (*graphql.RevokeIntegrationInstanceTokenResponse, error) {
+	return graphql.RevokeIntegrationInstanceToken(context.Background(), s.client.gqlClient, tokenID)
+}
+
+// NewInstanceTokenClient returns a Client that authenticates with an integration instance
+// token instead of the API key of this client. The account, region and HTTP client
+// settings are copied from this client.
+func (s *IntegrationService) NewInstanceTokenClient(token string) (*Client, error) {
+	config := s.client.config
+	config.APIKey = token
+
+	return NewClient(&config)
+}
+
+// CreateInstanceTokenClient issues a new token for the integration instance with id and
+// returns a Client that authenticates with it, together with the issued token so that
+// it can be revoked later.
+func (s *IntegrationService) CreateInstanceTokenClient(id string) (*Client, *graphql.IntegrationInstanceToken, error) {
+	resp, err := s.CreateInstanceToken(id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	token := resp.CreateIntegrationInstanceToken
+
+	client, err := s.NewInstanceTokenClient(token.Token)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return client, &token, nil
+}
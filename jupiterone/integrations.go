@@ -2,6 +2,7 @@ package jupiterone
 
 import (
 	"context"
+	"errors"
 
 	"github.com/jupiterone/jupiterone-client-go/jupiterone/graphql"
 )
@@ -9,33 +10,121 @@ import (
 // IntegrationService handles the integration-related API requests.
 type IntegrationService service
 
+var ErrIntegrationDefinitionNotFound = errors.New("integration definition not found")
+
 // ListDefinitions lists all the IntegrationDefinitions in the current account.
 // ListDefinitions returns a reference to an IntegrationDefinitionResponse object
 // which contains the Definitions and PageInfo used to request additional
 // definitions (if they exist).
 //
-// The first call to ListDefinitions should pass an empty string as the cursor. The caller
-// should check PageInfo.HasNextPage to see if there is additional data available.
-// If there is, the caller should pass PageInfo.Cursor on subsequent calls.
+// The first call to ListDefinitions should pass an empty string as the cursor. To paginate,
+// the caller should check PageInfo.HasNextPage and, if true, pass PageInfo.EndCursor as the
+// cursor on the next call. DefinitionsIterator does this for the caller.
 func (s *IntegrationService) ListDefinitions(cursor string) (*graphql.IntegrationDefinitionsResponse, error) {
 	return graphql.IntegrationDefinitions(context.Background(), s.client.gqlClient, cursor)
 }
 
+// DefinitionsIterator returns an Iterator over every IntegrationDefinition in the account.
+func (s *IntegrationService) DefinitionsIterator(ctx context.Context) *Iterator[graphql.IntegrationDefinition] {
+	return NewIterator(ctx, func(ctx context.Context, cursor string) (Page[graphql.IntegrationDefinition], error) {
+		resp, err := graphql.IntegrationDefinitions(ctx, s.client.gqlClient, cursor)
+		if err != nil {
+			return Page[graphql.IntegrationDefinition]{}, err
+		}
+
+		result := resp.IntegrationDefinitions
+		return Page[graphql.IntegrationDefinition]{
+			Items:      result.Definitions,
+			NextCursor: result.PageInfo.EndCursor,
+			HasNext:    result.PageInfo.HasNextPage,
+		}, nil
+	})
+}
+
 // GetDefinition gets a single Integration Definition by its id.
 func (s *IntegrationService) GetDefinition(id string) (*graphql.GetIntegrationDefinitionResponse, error) {
 	return graphql.GetIntegrationDefinition(context.Background(), s.client.gqlClient, id)
 }
 
+// FindDefinitionByType finds the IntegrationDefinition for an integration type,
+// such as "aws". It returns ErrIntegrationDefinitionNotFound if there is none.
+func (s *IntegrationService) FindDefinitionByType(integrationType string) (*graphql.IntegrationDefinition, error) {
+	resp, err := graphql.FindIntegrationDefinition(context.Background(), s.client.gqlClient, integrationType)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.FindIntegrationDefinition.Id == "" {
+		return nil, ErrIntegrationDefinitionNotFound
+	}
+
+	return &resp.FindIntegrationDefinition, nil
+}
+
 // ListInstances list the integration instances for the JupiterOne account.
 // ListInstances returns a reference to ListIntegrationInstancesResponse which
 // contains the Instances and the PageInfo used to request additional instances.
-// The first call to ListInstances should pass nil for the cursor. To paginate
-// through all instances, the caller should check if PageInfo.HasNextPage
-// is true and pass the PageInfo.Cursor from the response in subsequent calls.
+//
+// The first call to ListInstances should pass an empty string as the cursor. To paginate,
+// the caller should check PageInfo.HasNextPage and, if true, pass PageInfo.EndCursor as the
+// cursor on the next call. InstancesIterator does this for the caller.
 func (s *IntegrationService) ListInstances(cursor string) (*graphql.ListIntegrationInstancesResponse, error) {
 	return graphql.ListIntegrationInstances(context.Background(), s.client.gqlClient, cursor)
 }
 
+// InstancesIterator returns an Iterator over every IntegrationInstance in the account.
+func (s *IntegrationService) InstancesIterator(ctx context.Context) *Iterator[graphql.IntegrationInstance] {
+	return NewIterator(ctx, func(ctx context.Context, cursor string) (Page[graphql.IntegrationInstance], error) {
+		resp, err := graphql.ListIntegrationInstances(ctx, s.client.gqlClient, cursor)
+		if err != nil {
+			return Page[graphql.IntegrationInstance]{}, err
+		}
+
+		result := resp.IntegrationInstances
+		return Page[graphql.IntegrationInstance]{
+			Items:      result.Instances,
+			NextCursor: result.PageInfo.EndCursor,
+			HasNext:    result.PageInfo.HasNextPage,
+		}, nil
+	})
+}
+
+// SearchInstances lists the integration instances that match filter, optionally
+// restricted to a single definition. Pass an empty definitionID to search all definitions.
+// The filter is applied by the API. Pagination works like ListInstances; use
+// SearchInstancesIterator to walk every page.
+func (s *IntegrationService) SearchInstances(definitionID string, filter graphql.ListIntegrationInstancesSearchFilter, cursor string) (*graphql.SearchIntegrationInstancesResponse, error) {
+	return graphql.SearchIntegrationInstances(context.Background(), s.client.gqlClient, definitionID, cursor, filter)
+}
+
+// SearchInstancesIterator returns an Iterator over every integration instance that matches filter.
+func (s *IntegrationService) SearchInstancesIterator(ctx context.Context, definitionID string, filter graphql.ListIntegrationInstancesSearchFilter) *Iterator[graphql.IntegrationInstance] {
+	return NewIterator(ctx, func(ctx context.Context, cursor string) (Page[graphql.IntegrationInstance], error) {
+		resp, err := graphql.SearchIntegrationInstances(ctx, s.client.gqlClient, definitionID, cursor, filter)
+		if err != nil {
+			return Page[graphql.IntegrationInstance]{}, err
+		}
+
+		result := resp.IntegrationInstances
+		return Page[graphql.IntegrationInstance]{
+			Items:      result.Instances,
+			NextCursor: result.PageInfo.EndCursor,
+			HasNext:    result.PageInfo.HasNextPage,
+		}, nil
+	})
+}
+
+// CountInstances counts the integration instances of a definition.
+// Pass an empty definitionID to count every instance in the account.
+func (s *IntegrationService) CountInstances(definitionID string) (int, error) {
+	resp, err := graphql.CountIntegrationInstances(context.Background(), s.client.gqlClient, definitionID)
+	if err != nil {
+		return 0, err
+	}
+
+	return resp.IntegrationInstanceCount, nil
+}
+
 // CreateAnIntegrationInstance creates a new integration instance.
 func (s *IntegrationService) CreateInstance(instance graphql.CreateIntegrationInstanceInput) (*graphql.CreateInstanceResponse, error) {
 	return graphql.CreateInstance(context.Background(), s.client.gqlClient, instance)
@@ -48,26 +137,60 @@ func (s *IntegrationService) InvokeInstance(id string) (*graphql.InvokeInstanceR
 
 // ListInstanceJobs lists the jobs for a specific integration with InstanceId, id.
 // On the first call, a caller should pass the id of the integration, an empty cursor (""), and the size
-// of the results.
-//
-// To paginate, the caller should first check PageInfo.HasNextPage. If true, then the caller should pass
-// PageInfo.EndCursor as the cursor parameter.
+// of the results. For default API response size behavior, pass 0 as the size.
 //
-// For default API response size behavior, pass 0 as the size.
+// To paginate, the caller should check PageInfo.HasNextPage and, if true, pass PageInfo.EndCursor as
+// the cursor on the next call. InstanceJobsIterator does this for the caller.
 func (s *IntegrationService) ListInstanceJobs(id string, cursor string, size int) (*graphql.ListJobsResponse, error) {
 	return graphql.ListJobs(context.Background(), s.client.gqlClient, id, cursor, size)
 }
 
+// InstanceJobsIterator returns an Iterator over every job of the integration instance with id.
+// size is the page size; pass 0 for the default API behavior.
+func (s *IntegrationService) InstanceJobsIterator(ctx context.Context, id string, size int) *Iterator[graphql.IntegrationJob] {
+	return NewIterator(ctx, func(ctx context.Context, cursor string) (Page[graphql.IntegrationJob], error) {
+		resp, err := graphql.ListJobs(ctx, s.client.gqlClient, id, cursor, size)
+		if err != nil {
+			return Page[graphql.IntegrationJob]{}, err
+		}
+
+		result := resp.IntegrationJobs
+		return Page[graphql.IntegrationJob]{
+			Items:      result.Jobs,
+			NextCursor: result.PageInfo.EndCursor,
+			HasNext:    result.PageInfo.HasNextPage,
+		}, nil
+	})
+}
+
 // ListJobEvents lists the events for a single integration job. On the first call
 // the caller should pass the instance id of the integration, the integration job id,
 // an empty cursor (""), and the size to limit the number of events returned (0 is default api behavior).
 //
-// To paginate, the caller should first check PageInfo.HasNextPage. If true, then the caller should pass
-// PageInfo.EndCursor as the cursor parameter.
+// To paginate, the caller should check PageInfo.HasNextPage and, if true, pass PageInfo.EndCursor as
+// the cursor on the next call. JobEventsIterator does this for the caller.
 func (s *IntegrationService) ListJobEvents(instanceID string, jobID string, cursor string, size int) (*graphql.ListEventsResponse, error) {
 	return graphql.ListEvents(context.Background(), s.client.gqlClient, jobID, instanceID, cursor, size)
 }
 
+// JobEventsIterator returns an Iterator over every event of a single integration job.
+// size is the page size; pass 0 for the default API behavior.
+func (s *IntegrationService) JobEventsIterator(ctx context.Context, instanceID string, jobID string, size int) *Iterator[graphql.IntegrationEvent] {
+	return NewIterator(ctx, func(ctx context.Context, cursor string) (Page[graphql.IntegrationEvent], error) {
+		resp, err := graphql.ListEvents(ctx, s.client.gqlClient, jobID, instanceID, cursor, size)
+		if err != nil {
+			return Page[graphql.IntegrationEvent]{}, err
+		}
+
+		result := resp.IntegrationEvents
+		return Page[graphql.IntegrationEvent]{
+			Items:      result.Events,
+			NextCursor: result.PageInfo.EndCursor,
+			HasNext:    result.PageInfo.HasNextPage,
+		}, nil
+	})
+}
+
 // DeleteInstance deletes an integration instance by its id.
 func (s *IntegrationService) DeleteInstance(id string) (*graphql.DeleteIntegrationInstanceResponse, error) {
 	return graphql.DeleteIntegrationInstance(context.Background(), s.client.gqlClient, id)
@@ -80,3 +203,11 @@ func (s *IntegrationService) GetIntegrationInstance(id string) (*graphql.GetInte
 func (s *IntegrationService) UpdateIntegrationInstance(id string, payload graphql.UpdateIntegrationInstanceInput) (*graphql.UpdateIntegrationInstanceResponse, error) {
 	return graphql.UpdateIntegrationInstance(context.Background(), s.client.gqlClient, id, payload)
 }
+
+// BulkUpdateInstances applies the same update to every integration instance in ids,
+// for example to change the polling interval of many instances at once. Only the
+// fields set on update are changed. The response reports how many updates succeeded
+// and the ids of the instances that failed.
+func (s *IntegrationService) BulkUpdateInstances(ids []string, update graphql.UpdateIntegrationInstanceInput) (*graphql.BulkUpdateIntegrationInstancesResponse, error) {
+	return graphql.BulkUpdateIntegrationInstances(context.Background(), s.client.gqlClient, ids, update)
+}
@@ -0,0 +1,90 @@
+package jupiterone
+
+import "context"
+
+// Page is a single page of a cursor-paginated listing.
+type Page[T any] struct {
+	Items      []T
+	NextCursor string
+	HasNext    bool
+}
+
+// PageFetcher fetches the page of a listing that starts at cursor.
+// An empty cursor requests the first page.
+type PageFetcher[T any] func(ctx context.Context, cursor string) (Page[T], error)
+
+// Iterator walks every item of a cursor-paginated listing, fetching pages as needed.
+// It stops at the last page, on the first error, or when its context is done.
+//
+//	it := client.Integration.InstancesIterator(ctx)
+//	for it.Next() {
+//		instance := it.Item()
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type Iterator[T any] struct {
+	ctx    context.Context
+	fetch  PageFetcher[T]
+	items  []T
+	item   T
+	cursor string
+	done   bool
+	err    error
+}
+
+// NewIterator returns an Iterator over the pages returned by fetch.
+func NewIterator[T any](ctx context.Context, fetch PageFetcher[T]) *Iterator[T] {
+	return &Iterator[T]{ctx: ctx, fetch: fetch}
+}
+
+// Next advances to the next item, fetching the next page if the current one
+// is exhausted. It returns false when there are no more items or an error occurred.
+func (it *Iterator[T]) Next() bool {
+	if it.err != nil {
+		return false
+	}
+
+	if err := it.ctx.Err(); err != nil {
+		it.err = err
+		return false
+	}
+
+	for len(it.items) == 0 {
+		if it.done {
+			return false
+		}
+
+		page, err := it.fetch(it.ctx, it.cursor)
+		if err != nil {
+			it.err = err
+			return false
+		}
+
+		it.items = page.Items
+		it.cursor = page.NextCursor
+		it.done = !page.HasNext || page.NextCursor == ""
+	}
+
+	it.item, it.items = it.items[0], it.items[1:]
+	return true
+}
+
+// Item returns the current item. It is only valid after Next returned true.
+func (it *Iterator[T]) Item() T {
+	return it.item
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// All drains the iterator and returns the remaining items.
+func (it *Iterator[T]) All() ([]T, error) {
+	var items []T
+	for it.Next() {
+		items = append(items, it.Item())
+	}
+	return items, it.Err()
+}
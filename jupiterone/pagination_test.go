@@ -0,0 +1,76 @@
+package jupiterone
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakePages serves n pages of two ints each, using the page number as the cursor.
+func fakePages(n int, fetched *int) PageFetcher[int] {
+	return func(ctx context.Context, cursor string) (Page[int], error) {
+		*fetched++
+
+		page := 0
+		if cursor != "" {
+			page, _ = strconv.Atoi(cursor)
+		}
+
+		return Page[int]{
+			Items:      []int{page * 2, page*2 + 1},
+			NextCursor: strconv.Itoa(page + 1),
+			HasNext:    page+1 < n,
+		}, nil
+	}
+}
+
+func TestIteratorFollowsCursor(t *testing.T) {
+	fetched := 0
+
+	items, err := NewIterator(context.Background(), fakePages(3, &fetched)).All()
+	assert.NoError(t, err)
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5}, items)
+	assert.Equal(t, 3, fetched)
+}
+
+func TestIteratorStopsOnContextCancel(t *testing.T) {
+	fetched := 0
+	ctx, cancel := context.WithCancel(context.Background())
+
+	it := NewIterator(ctx, fakePages(100, &fetched))
+	assert.True(t, it.Next())
+	assert.True(t, it.Next())
+	cancel()
+
+	assert.False(t, it.Next())
+	assert.ErrorIs(t, it.Err(), context.Canceled)
+	assert.Equal(t, 1, fetched)
+}
+
+func TestAuditEventsIterator(t *testing.T) {
+	client := newGraphQLTestClient(t, graphQLHandler{
+		"GetAuditEventsForAccount": func(vars map[string]interface{}) interface{} {
+			if vars["cursor"] == nil {
+				return map[string]interface{}{
+					"getAuditEventsForAccount": map[string]interface{}{
+						"items":    []interface{}{map[string]interface{}{"id": "1"}},
+						"pageInfo": map[string]interface{}{"endCursor": "next", "hasNextPage": true},
+					},
+				}
+			}
+			return map[string]interface{}{
+				"getAuditEventsForAccount": map[string]interface{}{
+					"items":    []interface{}{map[string]interface{}{"id": "2"}},
+					"pageInfo": map[string]interface{}{"hasNextPage": false},
+				},
+			}
+		},
+	})
+
+	events, err := client.Audit.AuditEventsIterator(context.Background(), 0).All()
+	assert.NoError(t, err)
+	assert.Len(t, events, 2)
+	assert.Equal(t, "2", events[1].ID)
+}
@@ -1,10 +1,12 @@
 package jupiterone
 
 import (
+	"compress/gzip"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"time"
 
@@ -48,6 +50,18 @@ const (
 )
 
 func (q *QueryService) Query(qi QueryInput) (interface{}, error) {
+	return q.QueryWithContext(context.Background(), qi)
+}
+
+// ContextQueryService is implemented by query services whose queries can be
+// cancelled through a context, such as QueryService.
+type ContextQueryService interface {
+	QueryWithContext(ctx context.Context, qi QueryInput) (interface{}, error)
+}
+
+// QueryWithContext is Query with a context. Cancelling ctx stops the query request,
+// the polling for deferred results and the download of the results.
+func (q *QueryService) QueryWithContext(ctx context.Context, qi QueryInput) (interface{}, error) {
 	var queryResults interface{}
 
 	if qi.Flags == nil {
@@ -67,7 +81,7 @@ func (q *QueryService) Query(qi QueryInput) (interface{}, error) {
 	}
 
 	graphQLResponse, err := graphql.QueryJupiterOne(
-		context.Background(),
+		ctx,
 		q.client.gqlClient,
 		qi.Query,
 		qi.Cursor,
@@ -84,13 +98,13 @@ func (q *QueryService) Query(qi QueryInput) (interface{}, error) {
 		return queryResults, err
 	}
 
-	deferredResponse, err := q.pollDeferredURL(graphQLResponse.QueryV1.Url)
+	deferredResponse, err := q.pollDeferredURL(ctx, graphQLResponse.QueryV1.Url)
 	if err != nil {
 		fmt.Println("deferred request failed", err)
 		return queryResults, err
 	}
 
-	queryResults, err = q.getQueryResults(deferredResponse)
+	queryResults, err = q.getQueryResults(ctx, deferredResponse)
 
 	if err != nil {
 		fmt.Println("in query: failure to retrieve results ", err)
@@ -130,14 +144,20 @@ func (q *QueryService) AsTree(queryResults interface{}) (domain.QueryResult[doma
 	return queryResultsTree, nil
 }
 
-func (q *QueryService) getQueryResults(d domain.DeferredQueryURLResponse) (interface{}, error) {
+func (q *QueryService) getQueryResults(ctx context.Context, d domain.DeferredQueryURLResponse) (interface{}, error) {
 	var queryResults interface{}
 
-	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, d.URL, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, d.URL, nil)
 	if err != nil {
 		return nil, err
 	}
 
+	// Setting Accept-Encoding ourselves turns off the transport's transparent
+	// decompression, so the body has to be unwrapped below.
+	if q.client.gzipQueryResults {
+		req.Header.Set("Accept-Encoding", "gzip")
+	}
+
 	resp, err := q.client.httpClient.Do(req)
 	if err != nil {
 		return nil, err
@@ -148,7 +168,17 @@ func (q *QueryService) getQueryResults(d domain.DeferredQueryURLResponse) (inter
 		return nil, NetworkError(resp.Status)
 	}
 
-	decoder := json.NewDecoder(resp.Body)
+	body := io.Reader(resp.Body)
+	if resp.Header.Get("Content-Encoding") == "gzip" {
+		zr, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+		defer zr.Close()
+		body = zr
+	}
+
+	decoder := json.NewDecoder(body)
 	err = decoder.Decode(&queryResults)
 	if err != nil {
 		return nil, err
@@ -157,10 +187,10 @@ func (q *QueryService) getQueryResults(d domain.DeferredQueryURLResponse) (inter
 	return queryResults, nil
 }
 
-func (q *QueryService) pollDeferredURL(url string) (domain.DeferredQueryURLResponse, error) {
+func (q *QueryService) pollDeferredURL(ctx context.Context, url string) (domain.DeferredQueryURLResponse, error) {
 	var deferredResults domain.DeferredQueryURLResponse
 
-	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, url, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return deferredResults, err
 	}
@@ -183,8 +213,10 @@ func (q *QueryService) pollDeferredURL(url string) (domain.DeferredQueryURLRespo
 
 	if deferredResults.Status == inProgress {
 		fmt.Println("deferred results are in progress. sleeping...")
-		time.Sleep(sleepTime * time.Second)
-		return q.pollDeferredURL(url)
+		if err := sleepContext(ctx, sleepTime*time.Second); err != nil {
+			return deferredResults, err
+		}
+		return q.pollDeferredURL(ctx, url)
 	}
 
 	return deferredResults, nil
@@ -0,0 +1,134 @@
+package jupiterone
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/jupiterone/jupiterone-client-go/jupiterone/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+// queryResultsServer serves a deferred query result, gzipping it when the client asks.
+type queryResultsServer struct {
+	body      []byte
+	wireBytes int64
+	throttle  bool
+}
+
+func (q *queryResultsServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body := q.body
+
+	if strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		_, _ = zw.Write(q.body)
+		_ = zw.Close()
+
+		body = buf.Bytes()
+		w.Header().Set("Content-Encoding", "gzip")
+	}
+
+	atomic.AddInt64(&q.wireBytes, int64(len(body)))
+	if q.throttle {
+		simulateLink(len(body))
+	}
+
+	_, _ = w.Write(body)
+}
+
+func newQueryResultsServer(t testing.TB, throttle bool) (*queryResultsServer, *httptest.Server) {
+	results := map[string]interface{}{
+		"type": "list",
+		"data": createFakeEntities(1000),
+	}
+	body, err := json.Marshal(results)
+	if err != nil {
+		t.Fatalf("failed to marshal results: %v", err)
+	}
+
+	handler := &queryResultsServer{body: body, throttle: throttle}
+	return handler, httptest.NewServer(handler)
+}
+
+func TestGetQueryResultsGzip(t *testing.T) {
+	handler, server := newQueryResultsServer(t, false)
+	defer server.Close()
+
+	client, err := NewClient(&Config{APIKey: "a", AccountID: "a", GzipQueryResults: true})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	results, err := client.Query.(*QueryService).getQueryResults(context.Background(), domain.DeferredQueryURLResponse{URL: server.URL})
+	assert.NoError(t, err)
+
+	list, err := client.Query.AsList(results)
+	assert.NoError(t, err)
+	assert.Len(t, list.Data, 1000)
+	assert.Less(t, handler.wireBytes, int64(len(handler.body)), "results should be compressed on the wire")
+}
+
+func TestGetQueryResultsDefaultTransportCompresses(t *testing.T) {
+	handler, server := newQueryResultsServer(t, false)
+	defer server.Close()
+
+	client, err := NewClient(&Config{APIKey: "a", AccountID: "a"})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	_, err = client.Query.(*QueryService).getQueryResults(context.Background(), domain.DeferredQueryURLResponse{URL: server.URL})
+	assert.NoError(t, err)
+	assert.Less(t, handler.wireBytes, int64(len(handler.body)), "the default transport negotiates gzip without GzipQueryResults")
+}
+
+// benchmarkGetQueryResults measures fetching query results over a throttled link.
+// With the default transport the results are gzipped whether or not GzipQueryResults
+// is set; the option only changes the wire size when the transport disables compression.
+func benchmarkGetQueryResults(b *testing.B, gzipQueryResults bool, disableCompression bool) {
+	handler, server := newQueryResultsServer(b, true)
+	defer server.Close()
+
+	client, err := NewClient(&Config{APIKey: "a", AccountID: "a", GzipQueryResults: gzipQueryResults})
+	if err != nil {
+		b.Fatalf("failed to create client: %v", err)
+	}
+	if disableCompression {
+		client.httpClient = &http.Client{Transport: &http.Transport{DisableCompression: true}}
+	}
+	queryService := client.Query.(*QueryService)
+
+	b.SetBytes(int64(len(handler.body)))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := queryService.getQueryResults(context.Background(), domain.DeferredQueryURLResponse{URL: server.URL}); err != nil {
+			b.Fatalf("failed to get results: %v", err)
+		}
+	}
+
+	b.ReportMetric(float64(handler.wireBytes)/float64(b.N), "wire-B/op")
+}
+
+func BenchmarkGetQueryResultsDefaultTransport(b *testing.B) {
+	benchmarkGetQueryResults(b, false, false)
+}
+
+func BenchmarkGetQueryResultsDefaultTransportGzip(b *testing.B) {
+	benchmarkGetQueryResults(b, true, false)
+}
+
+func BenchmarkGetQueryResultsNoCompressionTransport(b *testing.B) {
+	benchmarkGetQueryResults(b, false, true)
+}
+
+func BenchmarkGetQueryResultsNoCompressionTransportGzip(b *testing.B) {
+	benchmarkGetQueryResults(b, true, true)
+}
@@ -22,45 +22,67 @@ type QuestionComplianceMetaData struct {
 	Controls     []string `json:"controls"`
 }
 
+// QuestionVariable is a variable that can be referenced from a question's queries.
+type QuestionVariable struct {
+	Name     string      `json:"name"`
+	Required bool        `json:"required"`
+	Default  interface{} `json:"default"`
+}
+
 type QuestionProperties struct {
 	Title       string                       `json:"title"`
 	Description string                       `json:"description"`
 	Tags        []string                     `json:"tags"`
 	Queries     []QuestionQuery              `json:"queries"`
 	Compliance  []QuestionComplianceMetaData `json:"compliance"`
+	// Name and Variables can only be set when a question is created.
+	Name      string             `json:"name,omitempty"`
+	Variables []QuestionVariable `json:"variables,omitempty"`
 }
 
 type Question struct {
-	ID          string                       `json:"id"`
-	Title       string                       `json:"title"`
-	Description string                       `json:"description"`
-	Tags        []string                     `json:"tags"`
-	Queries     []QuestionQuery              `json:"queries"`
-	Compliance  []QuestionComplianceMetaData `json:"compliance"`
+	ID                      string                       `json:"id"`
+	Name                    string                       `json:"name"`
+	Title                   string                       `json:"title"`
+	Description             string                       `json:"description"`
+	Tags                    []string                     `json:"tags"`
+	Queries                 []QuestionQuery              `json:"queries"`
+	Compliance              []QuestionComplianceMetaData `json:"compliance"`
+	Variables               []QuestionVariable           `json:"variables"`
+	AccountID               string                       `json:"accountId"`
+	IntegrationDefinitionID string                       `json:"integrationDefinitionId"`
 }
 
-// Get retrieves a question with the given id.
-func (s *QuestionService) Get(id string) (*Question, error) {
-	req := s.client.prepareRequest(`
-		query GetQuestionById ($id: ID!) {
-			question(id: $id) {
+// questionFields is the selection of Question fields decoded into Question.
+const questionFields = `
 				id
+				name
 				title
 				description
 				queries {
 					query
 					version
+					name
 				}
 				tags
+				variables {
+					name
+					required
+					default
+				}
 				compliance {
-					type
-					details {
-						name
-						description
-					}
+					standard
+					requirements
+					controls
 				}
 				accountId
-				integrationDefinitionId
+				integrationDefinitionId`
+
+// Get retrieves a question with the given id.
+func (s *QuestionService) Get(id string) (*Question, error) {
+	req := s.client.prepareRequest(`
+		query GetQuestionById ($id: ID!) {
+			question(id: $id) {` + questionFields + `
 			}
 		}
 	`)
@@ -86,27 +108,7 @@ func (s *QuestionService) Get(id string) (*Question, error) {
 func (s *QuestionService) Create(properties QuestionProperties) (*Question, error) {
 	req := s.client.prepareRequest(`
 		mutation CreateQuestion($question: CreateQuestionInput!) {
-			createQuestion(question: $question) {
-				id
-				title
-				description
-				queries {
-					query
-					version
-				}
-				tags
-				variables {
-					name
-					required
-					default
-				}
-				compliance {
-					type
-					details {
-						name
-						description
-					}
-				}
+			createQuestion(question: $question) {` + questionFields + `
 			}
 		}
 	`)
@@ -129,30 +131,14 @@ func (s *QuestionService) Create(properties QuestionProperties) (*Question, erro
 }
 
 // Update updates a question's properties given its id and new properties.
+// The name and variables of a question cannot be updated and are ignored.
 func (s *QuestionService) Update(id string, properties QuestionProperties) (*Question, error) {
+	properties.Name = ""
+	properties.Variables = nil
+
 	req := s.client.prepareRequest(`
 		mutation UpdateQuestion ($id: ID!, $update: QuestionUpdate!) {
-			updateQuestion(id: $id, update: $update) {
-				id
-				title
-				description
-				queries {
-					query
-					version
-				}
-				tags
-				variables {
-					name
-					required
-					default
-				}
-				compliance {
-					type
-					details {
-						name
-						description
-					}
-				}
+			updateQuestion(id: $id, update: $update) {` + questionFields + `
 			}
 		}
 	`)
@@ -0,0 +1,546 @@
+package jupiterone
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jupiterone/jupiterone-client-go/jupiterone/domain"
+	"gopkg.in/yaml.v3"
+)
+
+var ErrDuplicateQuestion = errors.New("more than one question has the same name or title")
+
+// QuestionFileFormat is the file format used by ExportQuestions.
+type QuestionFileFormat string
+
+const (
+	QuestionFileFormatYAML QuestionFileFormat = "yaml"
+	QuestionFileFormatJSON QuestionFileFormat = "json"
+)
+
+// QuestionDefinition is the portable form of a question kept in source control.
+// It leaves out the fields assigned by JupiterOne, such as the id and account.
+type QuestionDefinition struct {
+	// Name is a stable identifier for the question. Questions without a name are
+	// matched by Title instead.
+	Name        string                       `json:"name,omitempty" yaml:"name,omitempty"`
+	Title       string                       `json:"title" yaml:"title"`
+	Description string                       `json:"description,omitempty" yaml:"description,omitempty"`
+	Tags        []string                     `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Queries     []QuestionQuery              `json:"queries" yaml:"queries"`
+	Variables   []QuestionVariable           `json:"variables,omitempty" yaml:"variables,omitempty"`
+	Compliance  []QuestionComplianceMetaData `json:"compliance,omitempty" yaml:"compliance,omitempty"`
+}
+
+// NewQuestionDefinition returns the portable form of question. Tags are sorted so that
+// exports of unchanged questions are identical.
+func NewQuestionDefinition(question *Question) QuestionDefinition {
+	tags := append([]string(nil), question.Tags...)
+	sort.Strings(tags)
+
+	return QuestionDefinition{
+		Name:        question.Name,
+		Title:       question.Title,
+		Description: question.Description,
+		Tags:        tags,
+		Queries:     question.Queries,
+		Variables:   question.Variables,
+		Compliance:  question.Compliance,
+	}
+}
+
+// Properties returns the properties used to create or update the question.
+func (d *QuestionDefinition) Properties() QuestionProperties {
+	return QuestionProperties{
+		Name:        d.Name,
+		Title:       d.Title,
+		Description: d.Description,
+		Tags:        d.Tags,
+		Queries:     d.Queries,
+		Compliance:  d.Compliance,
+		Variables:   d.Variables,
+	}
+}
+
+// key is what definitions are matched on: the name if set, otherwise the title.
+func (d *QuestionDefinition) key() string {
+	if d.Name != "" {
+		return "name:" + d.Name
+	}
+	return "title:" + d.Title
+}
+
+// ExportQuestions writes every account question with any of tags (or every account
+// question if tags is empty) to dir, one file per question, and returns the paths written.
+// File names are derived from the question's name or title, and the output only changes
+// when the questions do.
+//
+// The files written are recorded in a manifest in dir, questionManifestName. Files the
+// previous export wrote that this one did not, such as those of deleted or renamed
+// questions, are removed so that a later import does not recreate them; files filtered
+// out by tags are kept. Other files are only removed if they define the same question as
+// a file this export wrote, such as a .yml copy of an exported .yaml file. Anything else
+// in dir is left alone.
+func (s *QuestionService) ExportQuestions(ctx context.Context, dir string, format QuestionFileFormat, tags []string) ([]string, error) {
+	questions, err := s.QuestionsIterator(ctx, ListQuestionsFilter{
+		Type: QuestionListTypeAccountOnly,
+		Tags: tags,
+	}).All()
+	if err != nil {
+		return nil, err
+	}
+
+	definitions := make([]QuestionDefinition, 0, len(questions))
+	for _, question := range questions {
+		definitions = append(definitions, NewQuestionDefinition(question))
+	}
+	sort.Slice(definitions, func(i, j int) bool { return definitions[i].key() < definitions[j].key() })
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	previous, err := readQuestionManifest(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var paths []string
+	used := map[string]bool{}
+	keys := map[string]bool{}
+
+	for _, definition := range definitions {
+		b, err := marshalQuestionDefinition(definition, format)
+		if err != nil {
+			return nil, err
+		}
+
+		base := questionFileName(definition)
+		name := base
+		for i := 2; used[name]; i++ {
+			name = fmt.Sprintf("%s-%d", base, i)
+		}
+		used[name] = true
+		keys[definition.key()] = true
+
+		path := filepath.Join(dir, name+"."+string(format))
+		if err := os.WriteFile(path, b, 0o644); err != nil {
+			return nil, err
+		}
+		paths = append(paths, path)
+	}
+
+	if err := removeStaleQuestionFiles(dir, previous, paths, keys, tags); err != nil {
+		return nil, err
+	}
+
+	return paths, nil
+}
+
+// questionManifestName is the file in which ExportQuestions records the files it wrote.
+// LoadQuestionDefinitions skips it, as it does every file whose name starts with a dot.
+const questionManifestName = ".questions-manifest.json"
+
+// questionManifest lists the files, relative to the export directory, that ExportQuestions owns.
+type questionManifest struct {
+	Files []string `json:"files"`
+}
+
+func readQuestionManifest(dir string) (*questionManifest, error) {
+	b, err := os.ReadFile(filepath.Join(dir, questionManifestName))
+	if errors.Is(err, os.ErrNotExist) {
+		return &questionManifest{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var manifest questionManifest
+	if err := json.Unmarshal(b, &manifest); err != nil {
+		return nil, fmt.Errorf("%s: %w", questionManifestName, err)
+	}
+	return &manifest, nil
+}
+
+// removeStaleQuestionFiles removes the question files in dir that an export should not
+// leave behind and records the files the export owns in the manifest. written are the
+// paths the export wrote, keys the keys of the definitions in them, previous the manifest
+// of the previous export and tags the tag filter of the export.
+func removeStaleQuestionFiles(dir string, previous *questionManifest, written []string, keys map[string]bool, tags []string) error {
+	manifest := questionManifest{Files: []string{}}
+	isWritten := map[string]bool{}
+	for _, path := range written {
+		manifest.Files = append(manifest.Files, filepath.Base(path))
+		isWritten[filepath.Base(path)] = true
+	}
+
+	owned := map[string]bool{}
+	for _, name := range previous.Files {
+		owned[name] = true
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !isQuestionFileName(name) || isWritten[name] {
+			continue
+		}
+
+		// Files that are not question definitions are never removed.
+		definition, err := readQuestionDefinition(filepath.Join(dir, name))
+		stale := err == nil && (keys[definition.key()] || (owned[name] && matchesAnyTag(definition.Tags, tags)))
+
+		if !stale {
+			// Files of an earlier export that this one filtered out stay owned.
+			if owned[name] {
+				manifest.Files = append(manifest.Files, name)
+			}
+			continue
+		}
+
+		if err := os.Remove(filepath.Join(dir, name)); err != nil {
+			return err
+		}
+	}
+
+	sort.Strings(manifest.Files)
+	b, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(filepath.Join(dir, questionManifestName), append(b, '\n'))
+}
+
+// matchesAnyTag reports whether questionTags includes any of tags, or tags is empty.
+func matchesAnyTag(questionTags []string, tags []string) bool {
+	if len(tags) == 0 {
+		return true
+	}
+	for _, tag := range tags {
+		for _, questionTag := range questionTags {
+			if questionTag == tag {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func marshalQuestionDefinition(definition QuestionDefinition, format QuestionFileFormat) ([]byte, error) {
+	switch format {
+	case QuestionFileFormatYAML:
+		return yaml.Marshal(definition)
+	case QuestionFileFormatJSON:
+		b, err := json.MarshalIndent(definition, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return append(b, '\n'), nil
+	default:
+		return nil, fmt.Errorf("unsupported question file format %q", format)
+	}
+}
+
+// questionFileName turns the question's name or title into a file name.
+func questionFileName(definition QuestionDefinition) string {
+	source := definition.Name
+	if source == "" {
+		source = definition.Title
+	}
+
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(source) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+			dash = false
+		} else if !dash && b.Len() > 0 {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+
+	name := strings.TrimSuffix(b.String(), "-")
+	if name == "" {
+		return "question"
+	}
+	return name
+}
+
+// LoadQuestionDefinitions reads every .yaml, .yml and .json file in dir as a
+// QuestionDefinition, in file name order. Files whose name starts with a dot are skipped.
+func LoadQuestionDefinitions(dir string) ([]QuestionDefinition, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var definitions []QuestionDefinition
+	for _, entry := range entries {
+		if entry.IsDir() || !isQuestionFileName(entry.Name()) {
+			continue
+		}
+
+		definition, err := readQuestionDefinition(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", entry.Name(), err)
+		}
+		definitions = append(definitions, *definition)
+	}
+
+	return definitions, nil
+}
+
+// isQuestionFileName reports whether name has the extension of a question file and is not hidden.
+func isQuestionFileName(name string) bool {
+	ext := filepath.Ext(name)
+	return !strings.HasPrefix(name, ".") && (ext == ".yaml" || ext == ".yml" || ext == ".json")
+}
+
+func readQuestionDefinition(path string) (*QuestionDefinition, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// YAML is a superset of JSON, so a single decoder handles both formats.
+	var definition QuestionDefinition
+	if err := yaml.Unmarshal(b, &definition); err != nil {
+		return nil, err
+	}
+	return &definition, nil
+}
+
+// QuestionImportOptions configures PlanQuestionImport.
+type QuestionImportOptions struct {
+	// Tags limits the account questions that are compared, as in ExportQuestions.
+	Tags []string
+	// Prune plans the deletion of compared account questions that have no definition.
+	Prune bool
+}
+
+// QuestionImportPlan is the set of changes needed to make the account questions
+// match a set of definitions.
+type QuestionImportPlan struct {
+	Creates []PlannedQuestionChange
+	Updates []PlannedQuestionChange
+	Deletes []PlannedQuestionChange
+}
+
+// PlannedQuestionChange is a single create, update or delete of a question.
+type PlannedQuestionChange struct {
+	QuestionID string
+	Title      string
+	Changes    []domain.PropertyChange
+
+	definition *QuestionDefinition
+}
+
+// IsEmpty reports whether applying the plan would change nothing.
+func (p *QuestionImportPlan) IsEmpty() bool {
+	return len(p.Creates) == 0 && len(p.Updates) == 0 && len(p.Deletes) == 0
+}
+
+// String renders the plan for review.
+func (p *QuestionImportPlan) String() string {
+	var b strings.Builder
+
+	write := func(symbol string, action string, change PlannedQuestionChange) {
+		fmt.Fprintf(&b, "%s %s %q", symbol, action, change.Title)
+		if change.QuestionID != "" {
+			fmt.Fprintf(&b, " (%s)", change.QuestionID)
+		}
+		b.WriteString("\n")
+
+		for _, c := range change.Changes {
+			fmt.Fprintf(&b, "    %s: %s -> %s\n", c.Name, formatPlanValue(c.Old), formatPlanValue(c.New))
+		}
+	}
+
+	for _, change := range p.Creates {
+		write("+", "create", change)
+	}
+	for _, change := range p.Updates {
+		write("~", "update", change)
+	}
+	for _, change := range p.Deletes {
+		write("-", "delete", change)
+	}
+
+	if p.IsEmpty() {
+		b.WriteString("no changes\n")
+	}
+
+	return b.String()
+}
+
+// PlanQuestionImport compares definitions with the account questions and returns the
+// creates, updates and deletes needed to make them match. Definitions are matched to
+// questions by name, or by title for definitions without a name. A definition whose name
+// no question has is matched by title to a question without a name. Nothing is changed
+// until the plan is passed to ApplyQuestionImport.
+//
+// The name and variables of an existing question cannot be updated, so they are only
+// compared when a question is created.
+func (s *QuestionService) PlanQuestionImport(ctx context.Context, definitions []QuestionDefinition, opts QuestionImportOptions) (*QuestionImportPlan, error) {
+	questions, err := s.QuestionsIterator(ctx, ListQuestionsFilter{
+		Type: QuestionListTypeAccountOnly,
+		Tags: opts.Tags,
+	}).All()
+	if err != nil {
+		return nil, err
+	}
+
+	byName := map[string]*Question{}
+	byTitle := map[string]*Question{}
+	unnamedByTitle := map[string]*Question{}
+	for _, question := range questions {
+		if question.Name != "" {
+			if _, ok := byName[question.Name]; ok {
+				return nil, fmt.Errorf("%w: %s", ErrDuplicateQuestion, question.Name)
+			}
+			byName[question.Name] = question
+		} else {
+			addQuestionByTitle(unnamedByTitle, question)
+		}
+		addQuestionByTitle(byTitle, question)
+	}
+
+	plan := &QuestionImportPlan{}
+	matched := map[string]bool{}
+	seen := map[string]bool{}
+
+	for i := range definitions {
+		definition := &definitions[i]
+
+		key := definition.key()
+		if seen[key] {
+			return nil, fmt.Errorf("%w: %s is defined more than once", ErrDuplicateQuestion, key)
+		}
+		seen[key] = true
+
+		question := byName[definition.Name]
+		if question == nil {
+			// Definitions without a name match any question with the title. A definition
+			// with a name that no question has yet matches a question without a name, so
+			// that naming the definition of an existing question does not duplicate it.
+			titles := byTitle
+			if definition.Name != "" {
+				titles = unnamedByTitle
+			}
+
+			var ok bool
+			if question, ok = titles[definition.Title]; ok && question == nil {
+				return nil, fmt.Errorf("%w: %s", ErrDuplicateQuestion, definition.Title)
+			}
+		}
+		if question != nil && matched[question.ID] {
+			return nil, fmt.Errorf("%w: %s matches the same question as another definition", ErrDuplicateQuestion, key)
+		}
+
+		change := PlannedQuestionChange{Title: definition.Title, definition: definition}
+
+		if question == nil {
+			change.Changes, err = diffQuestionDefinitions(nil, definition)
+			if err != nil {
+				return nil, err
+			}
+			plan.Creates = append(plan.Creates, change)
+			continue
+		}
+
+		matched[question.ID] = true
+		current := NewQuestionDefinition(question)
+
+		change.QuestionID = question.ID
+		change.Changes, err = diffQuestionDefinitions(&current, definition)
+		if err != nil {
+			return nil, err
+		}
+		if len(change.Changes) > 0 {
+			plan.Updates = append(plan.Updates, change)
+		}
+	}
+
+	if opts.Prune {
+		for _, question := range questions {
+			if !matched[question.ID] {
+				plan.Deletes = append(plan.Deletes, PlannedQuestionChange{QuestionID: question.ID, Title: question.Title})
+			}
+		}
+		sort.Slice(plan.Deletes, func(i, j int) bool { return plan.Deletes[i].Title < plan.Deletes[j].Title })
+	}
+
+	return plan, nil
+}
+
+// addQuestionByTitle adds question to byTitle, or marks its title as ambiguous with a
+// nil question if another question has the same title.
+func addQuestionByTitle(byTitle map[string]*Question, question *Question) {
+	if _, ok := byTitle[question.Title]; ok {
+		// Duplicate titles only matter if a definition is matched by title.
+		byTitle[question.Title] = nil
+		return
+	}
+	byTitle[question.Title] = question
+}
+
+// ApplyQuestionImport applies the creates, updates and deletes of plan, in that order.
+// It stops at the first error.
+func (s *QuestionService) ApplyQuestionImport(plan *QuestionImportPlan) error {
+	for _, change := range plan.Creates {
+		if _, err := s.Create(change.definition.Properties()); err != nil {
+			return fmt.Errorf("creating %q: %w", change.Title, err)
+		}
+	}
+
+	for _, change := range plan.Updates {
+		if _, err := s.Update(change.QuestionID, change.definition.Properties()); err != nil {
+			return fmt.Errorf("updating %q: %w", change.Title, err)
+		}
+	}
+
+	for _, change := range plan.Deletes {
+		if err := s.Delete(change.QuestionID); err != nil {
+			return fmt.Errorf("deleting %q: %w", change.Title, err)
+		}
+	}
+
+	return nil
+}
+
+// diffQuestionDefinitions lists the changes from current (nil for a new question) to desired.
+func diffQuestionDefinitions(current *QuestionDefinition, desired *QuestionDefinition) ([]domain.PropertyChange, error) {
+	normalized := *desired
+	normalized.Tags = append([]string(nil), desired.Tags...)
+	sort.Strings(normalized.Tags)
+
+	after, err := toPropertyMap(normalized)
+	if err != nil {
+		return nil, err
+	}
+
+	before := map[string]interface{}{}
+	if current != nil {
+		if before, err = toPropertyMap(current); err != nil {
+			return nil, err
+		}
+
+		for _, field := range []string{"name", "variables"} {
+			delete(before, field)
+			delete(after, field)
+		}
+	}
+
+	return diffProperties(before, after), nil
+}
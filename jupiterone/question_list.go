@@ -0,0 +1,314 @@
+package jupiterone
+
+import "context"
+
+// QuestionListType selects account questions, managed questions or both.
+type QuestionListType string
+
+const (
+	QuestionListTypeAccountOnly       QuestionListType = "ACCOUNT_ONLY"
+	QuestionListTypeManagedOnly       QuestionListType = "MANAGED_ONLY"
+	QuestionListTypeAccountAndManaged QuestionListType = "ACCOUNT_AND_MANAGED"
+)
+
+// ListQuestionsFilter narrows List. Zero-valued fields are not applied.
+type ListQuestionsFilter struct {
+	Type                          QuestionListType
+	SearchQuery                   string
+	IntegrationDefinitionID       string
+	ComplianceStandard            string
+	ComplianceStandardRequirement string
+	// Tags matches questions with any of the tags. MustTags requires all of them
+	// and ShouldTags ranks questions that have them higher.
+	Tags       []string
+	MustTags   []string
+	ShouldTags []string
+	Categories []string
+	// Limit is the page size; 0 uses the default API behavior.
+	Limit int
+}
+
+// ListQuestionsResponse is a single page of questions.
+type ListQuestionsResponse struct {
+	Questions []*Question `json:"questions"`
+	TotalHits int         `json:"totalHits"`
+	PageInfo  struct {
+		HasNextPage bool   `json:"hasNextPage"`
+		Cursor      string `json:"endCursor"`
+	} `json:"pageInfo"`
+}
+
+// QuestionTag is a question tag and the number of questions that use it.
+type QuestionTag struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// ListQuestionTagsResponse is a single page of question tags.
+type ListQuestionTagsResponse struct {
+	Tags     []QuestionTag `json:"tags"`
+	PageInfo struct {
+		HasNextPage bool   `json:"hasNextPage"`
+		Cursor      string `json:"endCursor"`
+	} `json:"pageInfo"`
+}
+
+// List lists the questions that match filter.
+//
+// The first call should use an empty string for cursor. To paginate, the caller
+// should check PageInfo.HasNextPage and, if true, pass PageInfo.Cursor as the
+// cursor on the next call. QuestionsIterator does this for the caller.
+func (s *QuestionService) List(filter ListQuestionsFilter, cursor string) (*ListQuestionsResponse, error) {
+	return s.list(context.Background(), filter, cursor)
+}
+
+// QuestionsIterator returns an Iterator over every question that matches filter.
+func (s *QuestionService) QuestionsIterator(ctx context.Context, filter ListQuestionsFilter) *Iterator[*Question] {
+	return NewIterator(ctx, func(ctx context.Context, cursor string) (Page[*Question], error) {
+		resp, err := s.list(ctx, filter, cursor)
+		if err != nil {
+			return Page[*Question]{}, err
+		}
+		return questionsPage(resp), nil
+	})
+}
+
+func (s *QuestionService) list(ctx context.Context, filter ListQuestionsFilter, cursor string) (*ListQuestionsResponse, error) {
+	req := s.client.prepareRequest(`
+		query ListQuestions(
+			$type: ListQuestionsType
+			$searchQuery: String
+			$integrationDefinitionId: String
+			$complianceStandard: String
+			$complianceStandardRequirement: String
+			$tags: [String]
+			$mustTags: [String]
+			$shouldTags: [String]
+			$categories: [String]
+			$limit: Int
+			$cursor: String
+		) {
+			questions(
+				type: $type
+				searchQuery: $searchQuery
+				integrationDefinitionId: $integrationDefinitionId
+				complianceStandard: $complianceStandard
+				complianceStandardRequirement: $complianceStandardRequirement
+				tags: $tags
+				mustTags: $mustTags
+				shouldTags: $shouldTags
+				categories: $categories
+				limit: $limit
+				cursor: $cursor
+			) {
+				questions {` + questionFields + `
+				}
+				totalHits
+				pageInfo {
+					endCursor
+					hasNextPage
+				}
+			}
+		}
+	`)
+
+	if filter.Type != "" {
+		req.Var("type", filter.Type)
+	}
+	if filter.SearchQuery != "" {
+		req.Var("searchQuery", filter.SearchQuery)
+	}
+	if filter.IntegrationDefinitionID != "" {
+		req.Var("integrationDefinitionId", filter.IntegrationDefinitionID)
+	}
+	if filter.ComplianceStandard != "" {
+		req.Var("complianceStandard", filter.ComplianceStandard)
+	}
+	if filter.ComplianceStandardRequirement != "" {
+		req.Var("complianceStandardRequirement", filter.ComplianceStandardRequirement)
+	}
+	if len(filter.Tags) > 0 {
+		req.Var("tags", filter.Tags)
+	}
+	if len(filter.MustTags) > 0 {
+		req.Var("mustTags", filter.MustTags)
+	}
+	if len(filter.ShouldTags) > 0 {
+		req.Var("shouldTags", filter.ShouldTags)
+	}
+	if len(filter.Categories) > 0 {
+		req.Var("categories", filter.Categories)
+	}
+	if filter.Limit != 0 {
+		req.Var("limit", filter.Limit)
+	}
+	if cursor != "" {
+		req.Var("cursor", cursor)
+	}
+
+	resp := struct {
+		Questions *ListQuestionsResponse `json:"questions"`
+	}{
+		Questions: &ListQuestionsResponse{},
+	}
+
+	if err := s.client.graphqlClient.Run(ctx, req, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Questions, nil
+}
+
+// FindSimilar lists the questions similar to the given terms, best match first.
+// limit is the page size; pass 0 for the default API behavior. Pagination works as in List;
+// FindSimilarIterator follows the cursor for the caller.
+func (s *QuestionService) FindSimilar(terms []string, limit int, cursor string) (*ListQuestionsResponse, error) {
+	return s.findSimilar(context.Background(), terms, limit, cursor)
+}
+
+// FindSimilarIterator returns an Iterator over every question similar to the given terms.
+func (s *QuestionService) FindSimilarIterator(ctx context.Context, terms []string, limit int) *Iterator[*Question] {
+	return NewIterator(ctx, func(ctx context.Context, cursor string) (Page[*Question], error) {
+		resp, err := s.findSimilar(ctx, terms, limit, cursor)
+		if err != nil {
+			return Page[*Question]{}, err
+		}
+		return questionsPage(resp), nil
+	})
+}
+
+func (s *QuestionService) findSimilar(ctx context.Context, terms []string, limit int, cursor string) (*ListQuestionsResponse, error) {
+	req := s.client.prepareRequest(`
+		query FindSimilarQuestions($termsToMatch: [String]!, $limit: Int, $cursor: String) {
+			findSimilarQuestions(termsToMatch: $termsToMatch, limit: $limit, cursor: $cursor) {
+				questions {` + questionFields + `
+				}
+				totalHits
+				pageInfo {
+					endCursor
+					hasNextPage
+				}
+			}
+		}
+	`)
+
+	req.Var("termsToMatch", terms)
+
+	if limit != 0 {
+		req.Var("limit", limit)
+	}
+	if cursor != "" {
+		req.Var("cursor", cursor)
+	}
+
+	resp := struct {
+		FindSimilarQuestions *ListQuestionsResponse `json:"findSimilarQuestions"`
+	}{
+		FindSimilarQuestions: &ListQuestionsResponse{},
+	}
+
+	if err := s.client.graphqlClient.Run(ctx, req, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.FindSimilarQuestions, nil
+}
+
+// Tags lists the tags used by questions with the number of questions using each.
+// limit is the page size; pass 0 for the default API behavior. Pagination works as in List;
+// TagsIterator follows the cursor for the caller.
+func (s *QuestionService) Tags(limit int, cursor string) (*ListQuestionTagsResponse, error) {
+	return s.tags(context.Background(), limit, cursor)
+}
+
+// TagsIterator returns an Iterator over every question tag.
+func (s *QuestionService) TagsIterator(ctx context.Context, limit int) *Iterator[QuestionTag] {
+	return NewIterator(ctx, func(ctx context.Context, cursor string) (Page[QuestionTag], error) {
+		resp, err := s.tags(ctx, limit, cursor)
+		if err != nil {
+			return Page[QuestionTag]{}, err
+		}
+
+		return Page[QuestionTag]{
+			Items:      resp.Tags,
+			NextCursor: resp.PageInfo.Cursor,
+			HasNext:    resp.PageInfo.HasNextPage,
+		}, nil
+	})
+}
+
+func (s *QuestionService) tags(ctx context.Context, limit int, cursor string) (*ListQuestionTagsResponse, error) {
+	req := s.client.prepareRequest(`
+		query QuestionsTags($limit: Int, $cursor: String) {
+			questionsTags(limit: $limit, cursor: $cursor) {
+				tags {
+					name
+					count
+				}
+				pageInfo {
+					endCursor
+					hasNextPage
+				}
+			}
+		}
+	`)
+
+	if limit != 0 {
+		req.Var("limit", limit)
+	}
+	if cursor != "" {
+		req.Var("cursor", cursor)
+	}
+
+	resp := struct {
+		QuestionsTags *ListQuestionTagsResponse `json:"questionsTags"`
+	}{
+		QuestionsTags: &ListQuestionTagsResponse{},
+	}
+
+	if err := s.client.graphqlClient.Run(ctx, req, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.QuestionsTags, nil
+}
+
+// Categories lists the names of the question categories.
+func (s *QuestionService) Categories() ([]string, error) {
+	req := s.client.prepareRequest(`
+		query QuestionsCategories {
+			questionsCategories {
+				categories {
+					name
+				}
+			}
+		}
+	`)
+
+	var resp struct {
+		QuestionsCategories struct {
+			Categories []struct {
+				Name string `json:"name"`
+			} `json:"categories"`
+		} `json:"questionsCategories"`
+	}
+
+	if err := s.client.graphqlClient.Run(context.Background(), req, &resp); err != nil {
+		return nil, err
+	}
+
+	categories := make([]string, 0, len(resp.QuestionsCategories.Categories))
+	for _, category := range resp.QuestionsCategories.Categories {
+		categories = append(categories, category.Name)
+	}
+
+	return categories, nil
+}
+
+func questionsPage(resp *ListQuestionsResponse) Page[*Question] {
+	return Page[*Question]{
+		Items:      resp.Questions,
+		NextCursor: resp.PageInfo.Cursor,
+		HasNext:    resp.PageInfo.HasNextPage,
+	}
+}
@@ -0,0 +1,168 @@
+package jupiterone
+
+import (
+	"context"
+	"time"
+)
+
+// QuestionOutput is a named output of a question evaluation, typically the
+// result of one of the question's queries.
+type QuestionOutput struct {
+	Name  string      `json:"name"`
+	Value interface{} `json:"value"`
+}
+
+// QuestionEvaluation is the result of evaluating a question.
+type QuestionEvaluation struct {
+	AnswerText string           `json:"answerText"`
+	Outputs    []QuestionOutput `json:"outputs"`
+}
+
+// Output returns the value of the output with the given name.
+func (e *QuestionEvaluation) Output(name string) (interface{}, bool) {
+	return findQuestionOutput(e.Outputs, name)
+}
+
+// QuestionResult is a single recorded result of a question.
+type QuestionResult struct {
+	Timestamp uint             `json:"timestamp"`
+	Outputs   []QuestionOutput `json:"outputs"`
+	// Version is the version of the question that produced the result.
+	Version string                      `json:"collectionOwnerVersion"`
+	Tag     string                      `json:"tag"`
+	RawData []QuestionRawDataDescriptor `json:"rawDataDescriptors"`
+}
+
+// QuestionRawDataDescriptor describes the raw data persisted for a query of a question result.
+type QuestionRawDataDescriptor struct {
+	Name                string `json:"name"`
+	RawDataKey          string `json:"rawDataKey"`
+	PersistedResultType string `json:"persistedResultType"`
+	RecordCount         int    `json:"recordCount"`
+	RecordCreateCount   int    `json:"recordCreateCount"`
+	RecordUpdateCount   int    `json:"recordUpdateCount"`
+	RecordDeleteCount   int    `json:"recordDeleteCount"`
+}
+
+// Time returns Timestamp, which is in milliseconds since the Unix epoch, as a time.Time.
+func (r *QuestionResult) Time() time.Time {
+	return time.UnixMilli(int64(r.Timestamp))
+}
+
+// Output returns the value of the output with the given name.
+func (r *QuestionResult) Output(name string) (interface{}, bool) {
+	return findQuestionOutput(r.Outputs, name)
+}
+
+// Evaluate evaluates the question with the given id and returns its outputs.
+func (s *QuestionService) Evaluate(ctx context.Context, id string) (*QuestionEvaluation, error) {
+	req := s.client.prepareRequest(`
+		query EvaluateQuestion($id: ID!) {
+			evaluateQuestion(id: $id) {
+				answerText
+				outputs {
+					name
+					value
+				}
+			}
+		}
+	`)
+
+	req.Var("id", id)
+
+	resp := struct {
+		EvaluateQuestion *QuestionEvaluation `json:"evaluateQuestion"`
+	}{
+		EvaluateQuestion: &QuestionEvaluation{},
+	}
+
+	if err := s.client.graphqlClient.Run(ctx, req, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.EvaluateQuestion, nil
+}
+
+// Results returns an Iterator over the recorded results of the question with the
+// given id between from and to.
+func (s *QuestionService) Results(ctx context.Context, id string, from time.Time, to time.Time) *Iterator[*QuestionResult] {
+	return NewIterator(ctx, func(ctx context.Context, cursor string) (Page[*QuestionResult], error) {
+		req := s.client.prepareRequest(`
+			query ListQuestionResults(
+				$collectionOwnerId: String!
+				$beginTimestamp: Long!
+				$endTimestamp: Long!
+				$cursor: String
+			) {
+				listCollectionResults(
+					collectionType: QUESTION
+					collectionOwnerId: $collectionOwnerId
+					beginTimestamp: $beginTimestamp
+					endTimestamp: $endTimestamp
+					cursor: $cursor
+				) {
+					results {
+						timestamp
+						outputs {
+							name
+							value
+						}
+						collectionOwnerVersion
+						tag
+						rawDataDescriptors {
+							name
+							rawDataKey
+							persistedResultType
+							recordCount
+							recordCreateCount
+							recordUpdateCount
+							recordDeleteCount
+						}
+					}
+					pageInfo {
+						endCursor
+						hasNextPage
+					}
+				}
+			}
+		`)
+
+		req.Var("collectionOwnerId", id)
+		req.Var("beginTimestamp", from.UnixMilli())
+		req.Var("endTimestamp", to.UnixMilli())
+
+		if cursor != "" {
+			req.Var("cursor", cursor)
+		}
+
+		var resp struct {
+			ListCollectionResults struct {
+				Results  []*QuestionResult `json:"results"`
+				PageInfo struct {
+					HasNextPage bool   `json:"hasNextPage"`
+					Cursor      string `json:"endCursor"`
+				} `json:"pageInfo"`
+			} `json:"listCollectionResults"`
+		}
+
+		if err := s.client.graphqlClient.Run(ctx, req, &resp); err != nil {
+			return Page[*QuestionResult]{}, err
+		}
+
+		result := resp.ListCollectionResults
+		return Page[*QuestionResult]{
+			Items:      result.Results,
+			NextCursor: result.PageInfo.Cursor,
+			HasNext:    result.PageInfo.HasNextPage,
+		}, nil
+	})
+}
+
+func findQuestionOutput(outputs []QuestionOutput, name string) (interface{}, bool) {
+	for _, output := range outputs {
+		if output.Name == name {
+			return output.Value, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,127 @@
+package jupiterone
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+var ErrMissingQuestionVariable = errors.New("required question variable is not set")
+
+// Run fetches the question with the given id and runs its queries through the Query
+// service. See RunQuestion.
+func (s *QuestionService) Run(ctx context.Context, id string, variables map[string]interface{}) (map[string]interface{}, error) {
+	question, err := s.Get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.RunQuestion(ctx, question, variables)
+}
+
+// RunQuestion runs the queries of question concurrently through the Query service, so
+// that a question can be tried before it is saved. The results are keyed by query name;
+// queries without a name are keyed "query0", "query1" and so on by position.
+//
+// The question's variables are resolved from variables, falling back to their defaults,
+// and passed to every query. An unset required variable returns ErrMissingQuestionVariable
+// without running anything. The first query error is returned.
+//
+// If the client's Query service implements ContextQueryService, as QueryService does, the
+// queries are run with a context derived from ctx and are cancelled when ctx is done or
+// when a query fails. Otherwise ctx only stops the wait for their results.
+func (s *QuestionService) RunQuestion(ctx context.Context, question *Question, variables map[string]interface{}) (map[string]interface{}, error) {
+	resolved, err := resolveQuestionVariables(question.Variables, variables)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type queryResult struct {
+		name   string
+		result interface{}
+		err    error
+	}
+
+	results := make(chan queryResult, len(question.Queries))
+	var wg sync.WaitGroup
+
+	for i, query := range question.Queries {
+		name := query.Name
+		if name == "" {
+			name = fmt.Sprintf("query%d", i)
+		}
+
+		wg.Add(1)
+		go func(name string, query QuestionQuery) {
+			defer wg.Done()
+
+			result, err := s.runQuery(ctx, QueryInput{
+				Query:     query.Query,
+				Variables: resolved,
+			})
+			if err != nil {
+				err = fmt.Errorf("query %s: %w", name, err)
+			}
+			results <- queryResult{name: name, result: result, err: err}
+		}(name, query)
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	byName := make(map[string]interface{}, len(question.Queries))
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case r, ok := <-results:
+			if !ok {
+				return byName, nil
+			}
+			if r.err != nil {
+				return nil, r.err
+			}
+			byName[r.name] = r.result
+		}
+	}
+}
+
+// runQuery runs qi with ctx if the Query service supports it.
+func (s *QuestionService) runQuery(ctx context.Context, qi QueryInput) (interface{}, error) {
+	if query, ok := s.client.Query.(ContextQueryService); ok {
+		return query.QueryWithContext(ctx, qi)
+	}
+	return s.client.Query.Query(qi)
+}
+
+// resolveQuestionVariables applies the defaults of the question's variables and
+// checks that the required ones are set.
+func resolveQuestionVariables(declared []QuestionVariable, values map[string]interface{}) (map[string]interface{}, error) {
+	resolved := make(map[string]interface{}, len(declared)+len(values))
+	for name, value := range values {
+		resolved[name] = value
+	}
+
+	for _, variable := range declared {
+		if value, ok := resolved[variable.Name]; ok && value != nil {
+			continue
+		}
+
+		if variable.Default != nil {
+			resolved[variable.Name] = variable.Default
+			continue
+		}
+
+		if variable.Required {
+			return nil, fmt.Errorf("%w: %s", ErrMissingQuestionVariable, variable.Name)
+		}
+	}
+
+	return resolved, nil
+}
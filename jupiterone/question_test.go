@@ -0,0 +1,482 @@
+package jupiterone
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jupiterone/jupiterone-client-go/jupiterone/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuestionsIterator(t *testing.T) {
+	var variables []map[string]interface{}
+
+	client := newGraphQLTestClient(t, graphQLHandler{
+		"ListQuestions": func(vars map[string]interface{}) interface{} {
+			variables = append(variables, vars)
+			if vars["cursor"] == nil {
+				return map[string]interface{}{
+					"questions": map[string]interface{}{
+						"questions": []interface{}{map[string]interface{}{
+							"id":      "q1",
+							"title":   "Unencrypted buckets",
+							"queries": []interface{}{map[string]interface{}{"name": "buckets", "query": "FIND aws_s3_bucket"}},
+							"tags":    []interface{}{"aws"},
+						}},
+						"totalHits": 2,
+						"pageInfo":  map[string]interface{}{"endCursor": "next", "hasNextPage": true},
+					},
+				}
+			}
+			return map[string]interface{}{
+				"questions": map[string]interface{}{
+					"questions": []interface{}{map[string]interface{}{"id": "q2"}},
+					"totalHits": 2,
+					"pageInfo":  map[string]interface{}{"hasNextPage": false},
+				},
+			}
+		},
+	})
+
+	questions, err := client.Question.QuestionsIterator(context.Background(), ListQuestionsFilter{
+		Type: QuestionListTypeAccountOnly,
+		Tags: []string{"aws"},
+	}).All()
+	assert.NoError(t, err)
+	assert.Len(t, questions, 2)
+	assert.Equal(t, "buckets", questions[0].Queries[0].Name)
+
+	assert.Equal(t, "ACCOUNT_ONLY", variables[0]["type"])
+	assert.Equal(t, []interface{}{"aws"}, variables[0]["tags"])
+	assert.NotContains(t, variables[0], "searchQuery")
+	assert.Equal(t, "next", variables[1]["cursor"])
+}
+
+func TestQuestionTagsAndCategories(t *testing.T) {
+	client := newGraphQLTestClient(t, graphQLHandler{
+		"QuestionsTags": func(vars map[string]interface{}) interface{} {
+			return map[string]interface{}{
+				"questionsTags": map[string]interface{}{
+					"tags":     []interface{}{map[string]interface{}{"name": "aws", "count": 3}},
+					"pageInfo": map[string]interface{}{"hasNextPage": false},
+				},
+			}
+		},
+		"QuestionsCategories": func(vars map[string]interface{}) interface{} {
+			return map[string]interface{}{
+				"questionsCategories": map[string]interface{}{
+					"categories": []interface{}{map[string]interface{}{"name": "Compliance"}},
+				},
+			}
+		},
+	})
+
+	tags, err := client.Question.TagsIterator(context.Background(), 0).All()
+	assert.NoError(t, err)
+	assert.Equal(t, []QuestionTag{{Name: "aws", Count: 3}}, tags)
+
+	categories, err := client.Question.Categories()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Compliance"}, categories)
+}
+
+func TestEvaluateQuestion(t *testing.T) {
+	client := newGraphQLTestClient(t, graphQLHandler{
+		"EvaluateQuestion": func(vars map[string]interface{}) interface{} {
+			return map[string]interface{}{
+				"evaluateQuestion": map[string]interface{}{
+					"answerText": "3 buckets",
+					"outputs":    []interface{}{map[string]interface{}{"name": "buckets", "value": 3}},
+				},
+			}
+		},
+	})
+
+	evaluation, err := client.Question.Evaluate(context.Background(), "q1")
+	assert.NoError(t, err)
+	assert.Equal(t, "3 buckets", evaluation.AnswerText)
+
+	value, ok := evaluation.Output("buckets")
+	assert.True(t, ok)
+	assert.Equal(t, float64(3), value)
+}
+
+func TestQuestionResults(t *testing.T) {
+	var variables []map[string]interface{}
+
+	client := newGraphQLTestClient(t, graphQLHandler{
+		"ListQuestionResults": func(vars map[string]interface{}) interface{} {
+			variables = append(variables, vars)
+			if vars["cursor"] == nil {
+				return map[string]interface{}{
+					"listCollectionResults": map[string]interface{}{
+						"results": []interface{}{map[string]interface{}{
+							"timestamp":              1000,
+							"collectionOwnerVersion": "2",
+							"outputs":                []interface{}{map[string]interface{}{"name": "buckets", "value": 3}},
+						}},
+						"pageInfo": map[string]interface{}{"endCursor": "next", "hasNextPage": true},
+					},
+				}
+			}
+			return map[string]interface{}{
+				"listCollectionResults": map[string]interface{}{
+					"results":  []interface{}{map[string]interface{}{"timestamp": 2000}},
+					"pageInfo": map[string]interface{}{"hasNextPage": false},
+				},
+			}
+		},
+	})
+
+	from := time.UnixMilli(500)
+	to := time.UnixMilli(5000)
+
+	results, err := client.Question.Results(context.Background(), "q1", from, to).All()
+	assert.NoError(t, err)
+	assert.Len(t, results, 2)
+	assert.Equal(t, "2", results[0].Version)
+	assert.Equal(t, time.UnixMilli(2000), results[1].Time())
+
+	assert.Equal(t, "q1", variables[0]["collectionOwnerId"])
+	assert.Equal(t, float64(500), variables[0]["beginTimestamp"])
+	assert.Equal(t, float64(5000), variables[0]["endTimestamp"])
+	assert.Equal(t, "next", variables[1]["cursor"])
+}
+
+var testQuestionPayload = map[string]interface{}{
+	"id":          "q1",
+	"name":        "unencrypted-buckets",
+	"title":       "Unencrypted buckets",
+	"description": "Buckets without encryption",
+	"queries": []interface{}{
+		map[string]interface{}{"name": "buckets", "query": "FIND aws_s3_bucket WITH encrypted = {{encrypted}}", "version": "v1"},
+	},
+	"tags":      []interface{}{"aws"},
+	"variables": []interface{}{map[string]interface{}{"name": "encrypted", "required": false, "default": false}},
+	"compliance": []interface{}{
+		map[string]interface{}{"standard": "CIS", "requirements": []interface{}{"2.1.1"}, "controls": []interface{}{}},
+	},
+	"accountId":               "account-1",
+	"integrationDefinitionId": "def-aws",
+}
+
+var testQuestion = &Question{
+	ID:          "q1",
+	Name:        "unencrypted-buckets",
+	Title:       "Unencrypted buckets",
+	Description: "Buckets without encryption",
+	Queries: []QuestionQuery{
+		{Name: "buckets", Query: "FIND aws_s3_bucket WITH encrypted = {{encrypted}}", Version: "v1"},
+	},
+	Tags:      []string{"aws"},
+	Variables: []QuestionVariable{{Name: "encrypted", Required: false, Default: false}},
+	Compliance: []QuestionComplianceMetaData{
+		{Standard: "CIS", Requirements: []string{"2.1.1"}, Controls: []string{}},
+	},
+	AccountID:               "account-1",
+	IntegrationDefinitionID: "def-aws",
+}
+
+func TestQuestionRoundTrip(t *testing.T) {
+	var created, updated map[string]interface{}
+
+	client := newGraphQLTestClient(t, graphQLHandler{
+		"GetQuestionById": func(vars map[string]interface{}) interface{} {
+			return map[string]interface{}{"question": testQuestionPayload}
+		},
+		"CreateQuestion": func(vars map[string]interface{}) interface{} {
+			created = vars["question"].(map[string]interface{})
+			return map[string]interface{}{"createQuestion": testQuestionPayload}
+		},
+		"UpdateQuestion": func(vars map[string]interface{}) interface{} {
+			updated = vars["update"].(map[string]interface{})
+			return map[string]interface{}{"updateQuestion": testQuestionPayload}
+		},
+	})
+
+	question, err := client.Question.Get("q1")
+	assert.NoError(t, err)
+	assert.Equal(t, testQuestion, question)
+
+	properties := QuestionProperties{
+		Name:        question.Name,
+		Title:       question.Title,
+		Description: question.Description,
+		Tags:        question.Tags,
+		Queries:     question.Queries,
+		Compliance:  question.Compliance,
+		Variables:   question.Variables,
+	}
+
+	question, err = client.Question.Create(properties)
+	assert.NoError(t, err)
+	assert.Equal(t, testQuestion, question)
+	assert.Equal(t, "unencrypted-buckets", created["name"])
+	assert.Equal(t, testQuestionPayload["variables"], created["variables"])
+	assert.Equal(t, testQuestionPayload["compliance"], created["compliance"])
+	assert.Equal(t, testQuestionPayload["queries"], created["queries"])
+
+	question, err = client.Question.Update("q1", properties)
+	assert.NoError(t, err)
+	assert.Equal(t, testQuestion, question)
+	assert.NotContains(t, updated, "name")
+	assert.NotContains(t, updated, "variables")
+}
+
+// recordingQueryService returns each query's variables as its result.
+type recordingQueryService struct {
+	QueryService
+}
+
+func (r *recordingQueryService) Query(qi QueryInput) (interface{}, error) {
+	return r.QueryWithContext(context.Background(), qi)
+}
+
+func (r *recordingQueryService) QueryWithContext(ctx context.Context, qi QueryInput) (interface{}, error) {
+	return map[string]interface{}{"query": qi.Query, "variables": qi.Variables}, nil
+}
+
+// blockingQueryService runs each query until its context is done and reports the
+// error the query stopped with on stopped.
+type blockingQueryService struct {
+	QueryService
+	stopped chan error
+}
+
+func (b *blockingQueryService) QueryWithContext(ctx context.Context, qi QueryInput) (interface{}, error) {
+	<-ctx.Done()
+	b.stopped <- ctx.Err()
+	return nil, ctx.Err()
+}
+
+func TestRunQuestionCancelsQueries(t *testing.T) {
+	client, err := NewClient(&Config{APIKey: "a", AccountID: "a"})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	query := &blockingQueryService{stopped: make(chan error, 2)}
+	client.Query = query
+
+	question := &Question{Queries: []QuestionQuery{{Query: "FIND Host"}, {Query: "FIND User"}}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err = client.Question.RunQuestion(ctx, question, nil)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	for i := 0; i < 2; i++ {
+		select {
+		case err := <-query.stopped:
+			assert.Error(t, err)
+		case <-time.After(time.Second):
+			t.Fatal("query was not cancelled")
+		}
+	}
+}
+
+func TestRunQuestion(t *testing.T) {
+	client, err := NewClient(&Config{APIKey: "a", AccountID: "a"})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	client.Query = &recordingQueryService{}
+
+	question := &Question{
+		Queries: []QuestionQuery{
+			{Name: "buckets", Query: "FIND aws_s3_bucket WITH encrypted = {{encrypted}}"},
+			{Query: "FIND aws_s3_bucket WITH region = {{region}}"},
+		},
+		Variables: []QuestionVariable{
+			{Name: "encrypted", Default: false},
+			{Name: "region", Required: true},
+		},
+	}
+
+	_, err = client.Question.RunQuestion(context.Background(), question, nil)
+	assert.ErrorIs(t, err, ErrMissingQuestionVariable)
+
+	results, err := client.Question.RunQuestion(context.Background(), question, map[string]interface{}{"region": "us-east-1"})
+	assert.NoError(t, err)
+	assert.Len(t, results, 2)
+
+	expectedVariables := map[string]interface{}{"encrypted": false, "region": "us-east-1"}
+	assert.Equal(t, expectedVariables, results["buckets"].(map[string]interface{})["variables"])
+	assert.Equal(t, "FIND aws_s3_bucket WITH region = {{region}}", results["query1"].(map[string]interface{})["query"])
+}
+
+func TestExportAndImportQuestions(t *testing.T) {
+	questions := []interface{}{
+		testQuestionPayload,
+		map[string]interface{}{"id": "q2", "title": "Stale question", "queries": []interface{}{map[string]interface{}{"query": "FIND User"}}},
+	}
+	var created, updated, deleted []string
+
+	client := newGraphQLTestClient(t, graphQLHandler{
+		"ListQuestions": func(vars map[string]interface{}) interface{} {
+			return map[string]interface{}{
+				"questions": map[string]interface{}{
+					"questions": questions,
+					"totalHits": len(questions),
+					"pageInfo":  map[string]interface{}{"hasNextPage": false},
+				},
+			}
+		},
+		"CreateQuestion": func(vars map[string]interface{}) interface{} {
+			created = append(created, vars["question"].(map[string]interface{})["title"].(string))
+			return map[string]interface{}{"createQuestion": map[string]interface{}{"id": "q3"}}
+		},
+		"UpdateQuestion": func(vars map[string]interface{}) interface{} {
+			updated = append(updated, vars["id"].(string))
+			return map[string]interface{}{"updateQuestion": map[string]interface{}{"id": vars["id"]}}
+		},
+		"DeleteQuestion": func(vars map[string]interface{}) interface{} {
+			deleted = append(deleted, vars["id"].(string))
+			return map[string]interface{}{"deleteQuestion": map[string]interface{}{"id": vars["id"]}}
+		},
+	})
+
+	dir := t.TempDir()
+	paths, err := client.Question.ExportQuestions(context.Background(), dir, QuestionFileFormatYAML, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "unencrypted-buckets.yaml"), filepath.Join(dir, "stale-question.yaml")}, paths)
+
+	first, err := os.ReadFile(paths[0])
+	assert.NoError(t, err)
+	_, err = client.Question.ExportQuestions(context.Background(), dir, QuestionFileFormatYAML, nil)
+	assert.NoError(t, err)
+	second, err := os.ReadFile(paths[0])
+	assert.NoError(t, err)
+	assert.Equal(t, string(first), string(second), "exports should be deterministic")
+
+	definitions, err := LoadQuestionDefinitions(dir)
+	assert.NoError(t, err)
+	assert.Len(t, definitions, 2)
+
+	plan, err := client.Question.PlanQuestionImport(context.Background(), definitions, QuestionImportOptions{Prune: true})
+	assert.NoError(t, err)
+	assert.True(t, plan.IsEmpty(), plan.String())
+
+	// Keep only the first question, change it and add a new one.
+	definitions = definitions[1:]
+	definitions[0].Description = "All buckets must be encrypted"
+	definitions = append(definitions, QuestionDefinition{Title: "New question", Queries: []QuestionQuery{{Query: "FIND Host"}}})
+
+	plan, err = client.Question.PlanQuestionImport(context.Background(), definitions, QuestionImportOptions{Prune: true})
+	assert.NoError(t, err)
+	if assert.Len(t, plan.Updates, 1) {
+		assert.Equal(t, []domain.PropertyChange{
+			{Name: "description", Old: "Buckets without encryption", New: "All buckets must be encrypted"},
+		}, plan.Updates[0].Changes)
+	}
+	assert.Contains(t, plan.String(), `- delete "Stale question" (q2)`)
+
+	assert.NoError(t, client.Question.ApplyQuestionImport(plan))
+	assert.Equal(t, []string{"New question"}, created)
+	assert.Equal(t, []string{"q1"}, updated)
+	assert.Equal(t, []string{"q2"}, deleted)
+}
+
+func TestExportQuestionsRemovesStaleFiles(t *testing.T) {
+	questions := []interface{}{
+		testQuestionPayload,
+		map[string]interface{}{"id": "q2", "title": "Removed question", "tags": []interface{}{"aws"}, "queries": []interface{}{map[string]interface{}{"query": "FIND User"}}},
+		map[string]interface{}{"id": "q3", "title": "Hosts", "tags": []interface{}{"hosts"}, "queries": []interface{}{map[string]interface{}{"query": "FIND Host"}}},
+	}
+
+	client := newGraphQLTestClient(t, graphQLHandler{
+		"ListQuestions": func(vars map[string]interface{}) interface{} {
+			// Filter by tag like the API does.
+			matching := []interface{}{}
+			for _, question := range questions {
+				tags, _ := vars["tags"].([]interface{})
+				if len(tags) == 0 || assert.ObjectsAreEqual(tags, question.(map[string]interface{})["tags"]) {
+					matching = append(matching, question)
+				}
+			}
+
+			return map[string]interface{}{
+				"questions": map[string]interface{}{
+					"questions": matching,
+					"pageInfo":  map[string]interface{}{"hasNextPage": false},
+				},
+			}
+		},
+	})
+
+	dir := t.TempDir()
+	notes := filepath.Join(dir, "README.md")
+	assert.NoError(t, os.WriteFile(notes, []byte("questions"), 0o644))
+	handWritten := filepath.Join(dir, "hand-written.yaml")
+	assert.NoError(t, os.WriteFile(handWritten, []byte("title: Hand written\nqueries:\n  - query: FIND Device\n"), 0o644))
+	duplicate := filepath.Join(dir, "buckets.yml")
+	assert.NoError(t, os.WriteFile(duplicate, []byte("name: unencrypted-buckets\ntitle: Old title\nqueries: []\n"), 0o644))
+
+	_, err := client.Question.ExportQuestions(context.Background(), dir, QuestionFileFormatYAML, nil)
+	assert.NoError(t, err)
+	assert.FileExists(t, filepath.Join(dir, "removed-question.yaml"))
+	assert.NoFileExists(t, duplicate, "a copy of an exported question is removed")
+
+	// Export only the aws questions after one of them was deleted.
+	questions = []interface{}{testQuestionPayload, questions[2]}
+
+	paths, err := client.Question.ExportQuestions(context.Background(), dir, QuestionFileFormatYAML, []string{"aws"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "unencrypted-buckets.yaml")}, paths)
+	assert.NoFileExists(t, filepath.Join(dir, "removed-question.yaml"))
+	assert.FileExists(t, filepath.Join(dir, "hosts.yaml"), "questions filtered out by tags are kept")
+	assert.FileExists(t, handWritten, "files that were not exported are left alone")
+	assert.FileExists(t, notes, "files that were not exported are left alone")
+
+	definitions, err := LoadQuestionDefinitions(dir)
+	assert.NoError(t, err)
+	assert.Len(t, definitions, 3, "the manifest is not loaded as a definition")
+
+	// The filtered-out file is still owned, so a later export without the question removes it.
+	questions = questions[:1]
+
+	_, err = client.Question.ExportQuestions(context.Background(), dir, QuestionFileFormatYAML, nil)
+	assert.NoError(t, err)
+	assert.NoFileExists(t, filepath.Join(dir, "hosts.yaml"))
+	assert.FileExists(t, handWritten)
+}
+
+func TestPlanQuestionImportMatchesUnnamedQuestionByTitle(t *testing.T) {
+	client := newGraphQLTestClient(t, graphQLHandler{
+		"ListQuestions": func(vars map[string]interface{}) interface{} {
+			return map[string]interface{}{
+				"questions": map[string]interface{}{
+					"questions": []interface{}{
+						map[string]interface{}{"id": "q1", "title": "Hosts", "queries": []interface{}{map[string]interface{}{"query": "FIND Host"}}},
+						map[string]interface{}{"id": "q2", "name": "users", "title": "Users", "queries": []interface{}{map[string]interface{}{"query": "FIND User"}}},
+					},
+					"pageInfo": map[string]interface{}{"hasNextPage": false},
+				},
+			}
+		},
+	})
+
+	definitions := []QuestionDefinition{
+		// Naming the definition of an unnamed question updates it rather than creating a copy.
+		{Name: "hosts", Title: "Hosts", Description: "All hosts", Queries: []QuestionQuery{{Query: "FIND Host"}}},
+		// A question with another name is not matched by title.
+		{Name: "other-users", Title: "Users", Queries: []QuestionQuery{{Query: "FIND User"}}},
+	}
+
+	plan, err := client.Question.PlanQuestionImport(context.Background(), definitions, QuestionImportOptions{})
+	assert.NoError(t, err)
+	if assert.Len(t, plan.Updates, 1) {
+		assert.Equal(t, "q1", plan.Updates[0].QuestionID)
+	}
+	if assert.Len(t, plan.Creates, 1) {
+		assert.Equal(t, "Users", plan.Creates[0].Title)
+	}
+
+	// Two definitions cannot match the same question.
+	definitions = append(definitions, QuestionDefinition{Title: "Hosts", Queries: []QuestionQuery{{Query: "FIND Host"}}})
+	_, err = client.Question.PlanQuestionImport(context.Background(), definitions, QuestionImportOptions{})
+	assert.ErrorIs(t, err, ErrDuplicateQuestion)
+}
@@ -2,31 +2,40 @@ package jupiterone
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
+	"fmt"
 	"log"
-
-	"github.com/mitchellh/mapstructure"
+	"strings"
 )
 
 type RuleService service
 
+var (
+	ErrInvalidRuleQuestionReference = errors.New("exactly one of QuestionID and QuestionName must be set")
+	ErrRuleInstanceNotFound         = errors.New("rule instance not found")
+)
+
 type RuleQuestion struct {
 	Queries []QuestionQuery `json:"queries"`
 }
 
-type RuleOperation struct {
-	When    []map[string]interface{} `json:"when"`
-	Actions []string                 `json:"actions"`
-}
-
 type QuestionRuleInstance struct {
 	BaseQuestionRuleInstanceProperties
-	ID        string `json:"id"`
-	AccountID string `json:"accountId"`
-	Version   int    `json:"version"`
-	Latest    bool   `json:"latest"`
-	Deleted   bool   `json:"deleted"`
-	Type      string `json:"type"`
+	ID                              string   `json:"id"`
+	AccountID                       string   `json:"accountId"`
+	Version                         int      `json:"version"`
+	Latest                          bool     `json:"latest"`
+	Deleted                         bool     `json:"deleted"`
+	Type                            string   `json:"type"`
+	Tags                            []string `json:"tags"`
+	NotifyOnFailure                 bool     `json:"notifyOnFailure"`
+	TriggerActionsOnNewEntitiesOnly bool     `json:"triggerActionsOnNewEntitiesOnly"`
+	// QuestionID and QuestionName are set for rules that reference a saved question.
+	QuestionID          string `json:"questionId"`
+	QuestionName        string `json:"questionName"`
+	LatestAlertID       string `json:"latestAlertId"`
+	LatestAlertIsActive bool   `json:"latestAlertIsActive"`
+	LastEvaluationEndOn int64  `json:"lastEvaluationEndOn"`
 }
 
 type UpdateQuestionRuleInstanceProperties struct {
@@ -41,26 +50,83 @@ type BaseQuestionRuleInstanceProperties struct {
 	SpecVersion     int                    `json:"specVersion"`
 	PollingInterval string                 `json:"pollingInterval"`
 	Outputs         []string               `json:"outputs"`
-	Operations      string                 `json:"operations"`
+	Operations      []RuleOperation        `json:"operations"`
 	Question        RuleQuestion           `json:"question"`
 	Templates       map[string]interface{} `json:"templates"`
 }
 
-type CreateQuestionRuleInstanceInput struct {
-	BaseQuestionRuleInstanceProperties
-	Operations []map[string]interface{} `json:"operations"`
+// CreateQuestionRuleInstanceInput is the input sent by Create.
+//
+// Deprecated: Create sends BaseQuestionRuleInstanceProperties, whose Operations are now typed.
+type CreateQuestionRuleInstanceInput = BaseQuestionRuleInstanceProperties
+
+// UpdateQuestionRuleInstanceInput is the input sent by Update.
+//
+// Deprecated: Update sends UpdateQuestionRuleInstanceProperties, whose Operations are now typed.
+type UpdateQuestionRuleInstanceInput = UpdateQuestionRuleInstanceProperties
+
+// RuleInstanceInputProperties are the properties shared by the inline and
+// referenced rule instance inputs.
+type RuleInstanceInputProperties struct {
+	Name            string                 `json:"name"`
+	Description     string                 `json:"description,omitempty"`
+	SpecVersion     int                    `json:"specVersion"`
+	Operations      []RuleOperation        `json:"operations"`
+	Outputs         []string               `json:"outputs,omitempty"`
+	PollingInterval string                 `json:"pollingInterval,omitempty"`
+	Templates       map[string]interface{} `json:"templates,omitempty"`
+	Tags            []string               `json:"tags,omitempty"`
+	// NotifyOnFailure and TriggerActionsOnNewEntitiesOnly are only sent when set, so an
+	// update leaves them unchanged when nil. Use Bool to set them.
+	NotifyOnFailure                 *bool `json:"notifyOnFailure,omitempty"`
+	TriggerActionsOnNewEntitiesOnly *bool `json:"triggerActionsOnNewEntitiesOnly,omitempty"`
 }
 
-type UpdateQuestionRuleInstanceInput struct {
-	UpdateQuestionRuleInstanceProperties
-	Operations []map[string]interface{} `json:"operations"`
+// Bool returns a pointer to v, for optional boolean fields such as
+// RuleInstanceInputProperties.NotifyOnFailure.
+func Bool(v bool) *bool {
+	return &v
 }
 
-// GetQuestionRuleInstanceByID - Fetches the QuestionRuleInstance by unique id.
-func (s *RuleService) GetByID(id string) (*QuestionRuleInstance, error) {
-	req := s.client.prepareRequest(`
-		query GetQuestionRuleInstance($id: ID!) {
-			questionRuleInstance (id: $id) {
+// RuleInstanceUpdateProperties are the properties that identify the rule
+// instance version being updated.
+type RuleInstanceUpdateProperties struct {
+	ID      string `json:"id"`
+	Version int    `json:"version"`
+	// State is deprecated by the API.
+	State         map[string]interface{} `json:"state,omitempty"`
+	LatestAlertID string                 `json:"latestAlertId,omitempty"`
+}
+
+// CreateInlineQuestionRuleInstanceInput creates a rule whose queries are defined in the rule.
+type CreateInlineQuestionRuleInstanceInput struct {
+	RuleInstanceInputProperties
+	Question RuleQuestion `json:"question"`
+}
+
+// CreateReferencedQuestionRuleInstanceInput creates a rule that runs a saved question,
+// referenced by exactly one of QuestionID and QuestionName.
+type CreateReferencedQuestionRuleInstanceInput struct {
+	RuleInstanceInputProperties
+	QuestionID   string `json:"questionId,omitempty"`
+	QuestionName string `json:"questionName,omitempty"`
+}
+
+type UpdateInlineQuestionRuleInstanceInput struct {
+	RuleInstanceUpdateProperties
+	RuleInstanceInputProperties
+	Question RuleQuestion `json:"question"`
+}
+
+type UpdateReferencedQuestionRuleInstanceInput struct {
+	RuleInstanceUpdateProperties
+	RuleInstanceInputProperties
+	QuestionID   string `json:"questionId,omitempty"`
+	QuestionName string `json:"questionName,omitempty"`
+}
+
+// ruleInstanceFields is the selection of QuestionRuleInstance fields decoded into QuestionRuleInstance.
+const ruleInstanceFields = `
 				id
 				name
 				description
@@ -72,6 +138,14 @@ func (s *RuleService) GetByID(id string) (*QuestionRuleInstance, error) {
 				accountId
 				type
 				templates
+				tags
+				notifyOnFailure
+				triggerActionsOnNewEntitiesOnly
+				questionId
+				questionName
+				latestAlertId
+				latestAlertIsActive
+				lastEvaluationEndOn
 				question {
 					queries {
 						name
@@ -83,28 +157,48 @@ func (s *RuleService) GetByID(id string) (*QuestionRuleInstance, error) {
 					when
 					actions
 				}
-				outputs
+				outputs`
+
+// GetQuestionRuleInstanceByID - Fetches the QuestionRuleInstance by unique id.
+// Returns ErrRuleInstanceNotFound if there is no such rule instance.
+func (s *RuleService) GetByID(id string) (*QuestionRuleInstance, error) {
+	return s.get(context.Background(), id, 0)
+}
+
+// get fetches the given version of a rule instance, or the latest version if version is 0.
+// It returns ErrRuleInstanceNotFound if the API returns no instance.
+func (s *RuleService) get(ctx context.Context, id string, version int) (*QuestionRuleInstance, error) {
+	req := s.client.prepareRequest(`
+		query GetQuestionRuleInstance($id: ID!, $version: Int) {
+			questionRuleInstance (id: $id, version: $version) {` + ruleInstanceFields + `
 			}
 		}
 	`)
 
 	req.Var("id", id)
 
-	var respData map[string]interface{}
-	if err := s.client.graphqlClient.Run(context.Background(), req, &respData); err != nil {
-		return nil, err
+	if version != 0 {
+		req.Var("version", version)
 	}
 
-	var decodedQuestionRuleInstance QuestionRuleInstance
-	err := mapstructure.Decode(respData["questionRuleInstance"], &decodedQuestionRuleInstance)
-	if err != nil {
+	var resp struct {
+		QuestionRuleInstance *QuestionRuleInstance `json:"questionRuleInstance"`
+	}
+
+	if err := s.client.graphqlClient.Run(ctx, req, &resp); err != nil {
 		return nil, err
 	}
 
-	return &decodedQuestionRuleInstance, nil
+	if resp.QuestionRuleInstance == nil {
+		return nil, fmt.Errorf("%w: %s", ErrRuleInstanceNotFound, id)
+	}
+
+	return resp.QuestionRuleInstance, nil
 }
 
 // CreateQuestionRuleInstance - Creates a question rule instance.
+//
+// Deprecated: Use CreateInline or CreateReferenced.
 func (s *RuleService) Create(createQuestionRuleInstanceInput BaseQuestionRuleInstanceProperties) (*QuestionRuleInstance, error) {
 	log.Println("Create question rule instance: " + createQuestionRuleInstanceInput.Name)
 
@@ -112,69 +206,27 @@ func (s *RuleService) Create(createQuestionRuleInstanceInput BaseQuestionRuleIns
 		mutation CreateQuestionRuleInstance ($instance: CreateQuestionRuleInstanceInput!) {
 			createQuestionRuleInstance (
 				instance: $instance
-			) {
-				id
-				name
-				description
-				version
-				specVersion
-				latest
-				deleted
-				accountId
-				type
-				pollingInterval
-				templates
-				question {
-					queries {
-						name
-						query
-						version
-					}
-				}
-				operations {
-					when
-					actions
-				}
-				outputs
+			) {` + ruleInstanceFields + `
 			}
 		}
 	`)
 
-	var input CreateQuestionRuleInstanceInput
-	input.Name = createQuestionRuleInstanceInput.Name
-	input.Description = createQuestionRuleInstanceInput.Description
-	input.SpecVersion = createQuestionRuleInstanceInput.SpecVersion
-	input.PollingInterval = createQuestionRuleInstanceInput.PollingInterval
-	input.Outputs = createQuestionRuleInstanceInput.Outputs
-	input.Question = createQuestionRuleInstanceInput.Question
-	input.Templates = createQuestionRuleInstanceInput.Templates
-
-	var deserializedOperationsMap []map[string]interface{}
-
-	err := json.Unmarshal([]byte(createQuestionRuleInstanceInput.Operations), &deserializedOperationsMap)
-	if err != nil {
-		return nil, err
-	}
-
-	input.Operations = deserializedOperationsMap
+	req.Var("instance", createQuestionRuleInstanceInput)
 
-	req.Var("instance", input)
-
-	var respData map[string]interface{}
-
-	if err := s.client.graphqlClient.Run(context.Background(), req, &respData); err != nil {
-		return nil, err
+	var resp struct {
+		CreateQuestionRuleInstance *QuestionRuleInstance `json:"createQuestionRuleInstance"`
 	}
 
-	var questionRuleInstance QuestionRuleInstance
-
-	if err := mapstructure.Decode(respData["createQuestionRuleInstance"], &questionRuleInstance); err != nil {
+	if err := s.client.graphqlClient.Run(context.Background(), req, &resp); err != nil {
 		return nil, err
 	}
 
-	return &questionRuleInstance, nil
+	return resp.CreateQuestionRuleInstance, nil
 }
 
+// Update updates a question rule instance.
+//
+// Deprecated: Use UpdateInline or UpdateReferenced.
 func (s *RuleService) Update(properties UpdateQuestionRuleInstanceProperties) (*QuestionRuleInstance, error) {
 	log.Println("Updating question rule instance: " + properties.Name)
 
@@ -182,68 +234,79 @@ func (s *RuleService) Update(properties UpdateQuestionRuleInstanceProperties) (*
 		mutation UpdateQuestionRuleInstance ($instance: UpdateQuestionRuleInstanceInput!) {
 			updateQuestionRuleInstance (
 				instance: $instance
-			) {
-				id
-				name
-				description
-				version
-				specVersion
-				latest
-				deleted
-				accountId
-				type
-				pollingInterval
-				templates
-				question {
-					queries {
-						name
-						query
-						version
-					}
-				}
-				operations {
-					when
-					actions
-				}
-				outputs
+			) {` + ruleInstanceFields + `
 			}
 		}
 	`)
 
-	var input UpdateQuestionRuleInstanceInput
-	input.ID = properties.ID
-	input.Version = properties.Version
-	input.Name = properties.Name
-	input.Description = properties.Description
-	input.SpecVersion = properties.SpecVersion
-	input.PollingInterval = properties.PollingInterval
-	input.Outputs = properties.Outputs
-	input.Question = properties.Question
-	input.Templates = properties.Templates
-
-	var deserializedOperationsMap []map[string]interface{}
-
-	err := json.Unmarshal([]byte(properties.Operations), &deserializedOperationsMap)
-	if err != nil {
+	req.Var("instance", properties)
+
+	var resp struct {
+		UpdateQuestionRuleInstance *QuestionRuleInstance `json:"updateQuestionRuleInstance"`
+	}
+
+	if err := s.client.graphqlClient.Run(context.Background(), req, &resp); err != nil {
 		return nil, err
 	}
 
-	input.Operations = deserializedOperationsMap
+	return resp.UpdateQuestionRuleInstance, nil
+}
 
-	req.Var("instance", input)
-	var respData map[string]interface{}
+// CreateInline creates a question rule instance whose queries are defined in the rule.
+func (s *RuleService) CreateInline(input CreateInlineQuestionRuleInstanceInput) (*QuestionRuleInstance, error) {
+	return s.runRuleInstanceMutation("createInlineQuestionRuleInstance", input)
+}
 
-	if err := s.client.graphqlClient.Run(context.Background(), req, &respData); err != nil {
-		return nil, err
+// CreateReferenced creates a question rule instance that runs a saved question.
+// Exactly one of input.QuestionID and input.QuestionName must be set.
+func (s *RuleService) CreateReferenced(input CreateReferencedQuestionRuleInstanceInput) (*QuestionRuleInstance, error) {
+	if (input.QuestionID == "") == (input.QuestionName == "") {
+		return nil, ErrInvalidRuleQuestionReference
+	}
+
+	return s.runRuleInstanceMutation("createReferencedQuestionRuleInstance", input)
+}
+
+// UpdateInline updates a question rule instance whose queries are defined in the rule.
+// input.Version must be the current version of the rule.
+func (s *RuleService) UpdateInline(input UpdateInlineQuestionRuleInstanceInput) (*QuestionRuleInstance, error) {
+	return s.runRuleInstanceMutation("updateInlineQuestionRuleInstance", input)
+}
+
+// UpdateReferenced updates a question rule instance that runs a saved question.
+// input.Version must be the current version of the rule, and exactly one of
+// input.QuestionID and input.QuestionName must be set.
+func (s *RuleService) UpdateReferenced(input UpdateReferencedQuestionRuleInstanceInput) (*QuestionRuleInstance, error) {
+	if (input.QuestionID == "") == (input.QuestionName == "") {
+		return nil, ErrInvalidRuleQuestionReference
 	}
 
-	var questionRuleInstance QuestionRuleInstance
+	return s.runRuleInstanceMutation("updateReferencedQuestionRuleInstance", input)
+}
+
+// runRuleInstanceMutation runs the rule instance mutation with the given field name, whose
+// input type is the field name with the first letter capitalized followed by "Input".
+func (s *RuleService) runRuleInstanceMutation(field string, input interface{}) (*QuestionRuleInstance, error) {
+	operation := strings.ToUpper(field[:1]) + field[1:]
+
+	req := s.client.prepareRequest(fmt.Sprintf(`
+		mutation %s ($instance: %sInput!) {
+			%s (
+				instance: $instance
+			) {%s
+			}
+		}
+	`, operation, operation, field, ruleInstanceFields))
+
+	req.Var("instance", input)
+
+	var resp map[string]*QuestionRuleInstance
 
-	if err := mapstructure.Decode(respData["updateQuestionRuleInstance"], &questionRuleInstance); err != nil {
+	if err := s.client.graphqlClient.Run(context.Background(), req, &resp); err != nil {
 		return nil, err
 	}
 
-	return &questionRuleInstance, nil
+	return resp[field], nil
 }
 
 func (s *RuleService) Delete(id string) error {
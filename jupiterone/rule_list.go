@@ -0,0 +1,217 @@
+package jupiterone
+
+import (
+	"context"
+	"errors"
+	"strconv"
+)
+
+// ReportRuleInstance is a rule instance that evaluates a report instead of a question.
+type ReportRuleInstance struct {
+	ID                              string          `json:"id"`
+	AccountID                       string          `json:"accountId"`
+	Name                            string          `json:"name"`
+	Description                     string          `json:"description"`
+	Version                         int             `json:"version"`
+	SpecVersion                     int             `json:"specVersion"`
+	Latest                          bool            `json:"latest"`
+	Deleted                         bool            `json:"deleted"`
+	Type                            string          `json:"type"`
+	NotifyOnFailure                 bool            `json:"notifyOnFailure"`
+	TriggerActionsOnNewEntitiesOnly bool            `json:"triggerActionsOnNewEntitiesOnly"`
+	Operations                      []RuleOperation `json:"operations"`
+	Outputs                         []string        `json:"outputs"`
+	PollingInterval                 string          `json:"pollingInterval"`
+	LatestAlertID                   string          `json:"latestAlertId"`
+	LatestAlertIsActive             bool            `json:"latestAlertIsActive"`
+	LastEvaluationEndOn             int64           `json:"lastEvaluationEndOn"`
+}
+
+// ListRulesFilter narrows List. Zero-valued fields are not applied.
+type ListRulesFilter struct {
+	// Tags matches rules with any of the tags.
+	Tags []string
+	// Limit is the page size; 0 uses the default API behavior.
+	Limit int
+}
+
+// ListRulesResponse is a single page of rule instances.
+type ListRulesResponse struct {
+	QuestionInstances []*QuestionRuleInstance `json:"questionInstances"`
+	ReportInstances   []*ReportRuleInstance   `json:"reportInstances"`
+	PageInfo          struct {
+		HasNextPage bool   `json:"hasNextPage"`
+		Cursor      string `json:"endCursor"`
+	} `json:"pageInfo"`
+}
+
+// RuleInstance is a rule instance returned by RulesIterator. Exactly one of
+// Question and Report is set.
+type RuleInstance struct {
+	Question *QuestionRuleInstance
+	Report   *ReportRuleInstance
+}
+
+// reportRuleInstanceFields is the selection of ReportRuleInstance fields decoded into ReportRuleInstance.
+const reportRuleInstanceFields = `
+				id
+				accountId
+				name
+				description
+				version
+				specVersion
+				latest
+				deleted
+				type
+				notifyOnFailure
+				triggerActionsOnNewEntitiesOnly
+				operations {
+					when
+					actions
+				}
+				outputs
+				pollingInterval
+				latestAlertId
+				latestAlertIsActive
+				lastEvaluationEndOn`
+
+// List lists the rule instances that match filter.
+//
+// The first call should use an empty string for cursor. To paginate, the caller
+// should check PageInfo.HasNextPage and, if true, pass PageInfo.Cursor as the
+// cursor on the next call. RulesIterator does this for the caller.
+func (s *RuleService) List(filter ListRulesFilter, cursor string) (*ListRulesResponse, error) {
+	return s.list(context.Background(), filter, cursor)
+}
+
+// RulesIterator returns an Iterator over every rule instance that matches filter.
+// The question instances of each page come before its report instances.
+func (s *RuleService) RulesIterator(ctx context.Context, filter ListRulesFilter) *Iterator[RuleInstance] {
+	return NewIterator(ctx, func(ctx context.Context, cursor string) (Page[RuleInstance], error) {
+		resp, err := s.list(ctx, filter, cursor)
+		if err != nil {
+			return Page[RuleInstance]{}, err
+		}
+
+		items := make([]RuleInstance, 0, len(resp.QuestionInstances)+len(resp.ReportInstances))
+		for _, instance := range resp.QuestionInstances {
+			items = append(items, RuleInstance{Question: instance})
+		}
+		for _, instance := range resp.ReportInstances {
+			items = append(items, RuleInstance{Report: instance})
+		}
+
+		return Page[RuleInstance]{
+			Items:      items,
+			NextCursor: resp.PageInfo.Cursor,
+			HasNext:    resp.PageInfo.HasNextPage,
+		}, nil
+	})
+}
+
+func (s *RuleService) list(ctx context.Context, filter ListRulesFilter, cursor string) (*ListRulesResponse, error) {
+	req := s.client.prepareRequest(`
+		query ListRuleInstances($limit: Int, $cursor: String, $filters: ListRuleInstancesFilters) {
+			listRuleInstances(limit: $limit, cursor: $cursor, filters: $filters) {
+				questionInstances {` + ruleInstanceFields + `
+				}
+				reportInstances {` + reportRuleInstanceFields + `
+				}
+				pageInfo {
+					endCursor
+					hasNextPage
+				}
+			}
+		}
+	`)
+
+	if len(filter.Tags) > 0 {
+		req.Var("filters", map[string]interface{}{"tags": filter.Tags})
+	}
+	if filter.Limit != 0 {
+		req.Var("limit", filter.Limit)
+	}
+	if cursor != "" {
+		req.Var("cursor", cursor)
+	}
+
+	resp := struct {
+		ListRuleInstances *ListRulesResponse `json:"listRuleInstances"`
+	}{
+		ListRuleInstances: &ListRulesResponse{},
+	}
+
+	if err := s.client.graphqlClient.Run(ctx, req, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.ListRuleInstances, nil
+}
+
+// Tags lists the tags used by question rules.
+func (s *RuleService) Tags() ([]string, error) {
+	req := s.client.prepareRequest(`
+		query QuestionRuleTags {
+			questionRuleTags
+		}
+	`)
+
+	var resp struct {
+		QuestionRuleTags []string `json:"questionRuleTags"`
+	}
+
+	if err := s.client.graphqlClient.Run(context.Background(), req, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.QuestionRuleTags, nil
+}
+
+// GetVersion fetches the given version of the question rule instance with the given id.
+// Returns ErrRuleInstanceNotFound if there is no such version.
+func (s *RuleService) GetVersion(id string, version int) (*QuestionRuleInstance, error) {
+	return s.get(context.Background(), id, version)
+}
+
+// Versions returns an Iterator over the versions of the question rule instance with
+// the given id, from the latest version down to version 1. Older versions the API does
+// not return, or returns a different version for, are skipped; a missing rule instance
+// is reported as ErrRuleInstanceNotFound.
+func (s *RuleService) Versions(ctx context.Context, id string) *Iterator[*QuestionRuleInstance] {
+	return NewIterator(ctx, func(ctx context.Context, cursor string) (Page[*QuestionRuleInstance], error) {
+		// The cursor is the next version to fetch; it is empty for the latest version.
+		version := 0
+		if cursor != "" {
+			var err error
+			if version, err = strconv.Atoi(cursor); err != nil {
+				return Page[*QuestionRuleInstance]{}, err
+			}
+		}
+
+		instance, err := s.get(ctx, id, version)
+		if version != 0 {
+			// Skip versions the API does not return, or returns another version for.
+			if errors.Is(err, ErrRuleInstanceNotFound) || (err == nil && instance.Version != version) {
+				return Page[*QuestionRuleInstance]{
+					NextCursor: strconv.Itoa(version - 1),
+					HasNext:    version > 1,
+				}, nil
+			}
+		}
+		if err != nil {
+			return Page[*QuestionRuleInstance]{}, err
+		}
+
+		// The next cursor follows the requested version; only the latest version is
+		// taken from the response.
+		if version == 0 {
+			version = instance.Version
+		}
+
+		return Page[*QuestionRuleInstance]{
+			Items:      []*QuestionRuleInstance{instance},
+			NextCursor: strconv.Itoa(version - 1),
+			HasNext:    version > 1,
+		}, nil
+	})
+}
@@ -0,0 +1,305 @@
+package jupiterone
+
+import "encoding/json"
+
+// RuleActionType is the type of a rule action.
+type RuleActionType string
+
+const (
+	RuleActionSetProperty      RuleActionType = "SET_PROPERTY"
+	RuleActionCreateAlert      RuleActionType = "CREATE_ALERT"
+	RuleActionSendEmail        RuleActionType = "SEND_EMAIL"
+	RuleActionSendSlackMessage RuleActionType = "SEND_SLACK_MESSAGE"
+	RuleActionWebhook          RuleActionType = "WEBHOOK"
+	RuleActionTagEntities      RuleActionType = "TAG_ENTITIES"
+	RuleActionCreateJiraTicket RuleActionType = "CREATE_JIRA_TICKET"
+)
+
+// RuleOperation runs Actions when the results of a rule's queries match When.
+type RuleOperation struct {
+	// When is nil for operations that always run.
+	When    *RuleCondition `json:"when"`
+	Actions []RuleAction   `json:"actions"`
+}
+
+// RuleCondition is the condition of a rule operation, such as
+//
+//	{"type": "FILTER", "condition": ["AND", ["queries.query0.total", ">", 0]]}
+//
+// Fields without a typed field, and typed fields whose zero value would otherwise be
+// omitted, are kept in Extra, so conditions read from the API are sent back unchanged.
+type RuleCondition struct {
+	Type        string        `json:"type"`
+	SpecVersion int           `json:"specVersion,omitempty"`
+	Condition   []interface{} `json:"condition"`
+
+	Extra map[string]interface{} `json:"-"`
+}
+
+// RuleAction is a single action of a rule operation. Only the fields used by
+// Type are set. Fields without a typed field, including those of action types
+// not listed here, and typed fields whose zero value would otherwise be omitted
+// are kept in Extra, so actions read from the API are sent back unchanged.
+type RuleAction struct {
+	Type RuleActionType `json:"type"`
+	ID   string         `json:"id,omitempty"`
+
+	// SET_PROPERTY
+	TargetProperty string      `json:"targetProperty,omitempty"`
+	TargetValue    interface{} `json:"targetValue,omitempty"`
+
+	// SEND_EMAIL, SEND_SLACK_MESSAGE and WEBHOOK
+	Recipients []string          `json:"recipients,omitempty"`
+	Channels   []string          `json:"channels,omitempty"`
+	Endpoint   string            `json:"endpoint,omitempty"`
+	Method     string            `json:"method,omitempty"`
+	Headers    map[string]string `json:"headers,omitempty"`
+	Body       interface{}       `json:"body,omitempty"`
+
+	// SEND_SLACK_MESSAGE and CREATE_JIRA_TICKET
+	IntegrationInstanceID string `json:"integrationInstanceId,omitempty"`
+
+	// TAG_ENTITIES
+	Entities string          `json:"entities,omitempty"`
+	Tags     []RuleEntityTag `json:"tags,omitempty"`
+
+	// CREATE_JIRA_TICKET
+	EntityClass      string                 `json:"entityClass,omitempty"`
+	Summary          string                 `json:"summary,omitempty"`
+	IssueType        string                 `json:"issueType,omitempty"`
+	Project          string                 `json:"project,omitempty"`
+	AdditionalFields map[string]interface{} `json:"additionalFields,omitempty"`
+	AutoResolve      *bool                  `json:"autoResolve,omitempty"`
+	ResolvedStatus   string                 `json:"resolvedStatus,omitempty"`
+
+	Extra map[string]interface{} `json:"-"`
+}
+
+// RuleEntityTag is a tag set by a TAG_ENTITIES action.
+type RuleEntityTag struct {
+	Name  string      `json:"name"`
+	Value interface{} `json:"value"`
+}
+
+// RuleJiraTicket holds the fields of a CREATE_JIRA_TICKET action.
+type RuleJiraTicket struct {
+	IntegrationInstanceID string
+	EntityClass           string
+	Summary               string
+	IssueType             string
+	Project               string
+	AdditionalFields      map[string]interface{}
+	AutoResolve           bool
+	ResolvedStatus        string
+}
+
+func (c RuleCondition) MarshalJSON() ([]byte, error) {
+	type plain RuleCondition
+	return marshalWithExtra(plain(c), c.Extra)
+}
+
+func (c *RuleCondition) UnmarshalJSON(data []byte) error {
+	type plain RuleCondition
+	var p plain
+	if err := json.Unmarshal(data, &p); err != nil {
+		return err
+	}
+
+	extra, err := unmarshalExtra(data, p)
+	if err != nil {
+		return err
+	}
+
+	*c = RuleCondition(p)
+	c.Extra = extra
+	return nil
+}
+
+func (a RuleAction) MarshalJSON() ([]byte, error) {
+	type plain RuleAction
+	return marshalWithExtra(plain(a), a.Extra)
+}
+
+func (a *RuleAction) UnmarshalJSON(data []byte) error {
+	type plain RuleAction
+	var p plain
+	if err := json.Unmarshal(data, &p); err != nil {
+		return err
+	}
+
+	extra, err := unmarshalExtra(data, p)
+	if err != nil {
+		return err
+	}
+
+	*a = RuleAction(p)
+	a.Extra = extra
+	return nil
+}
+
+// marshalWithExtra marshals v and adds the fields in extra that v does not set.
+func marshalWithExtra(v interface{}, extra map[string]interface{}) ([]byte, error) {
+	b, err := json.Marshal(v)
+	if err != nil || len(extra) == 0 {
+		return b, err
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return nil, err
+	}
+
+	for key, value := range extra {
+		if _, ok := fields[key]; !ok {
+			fields[key] = value
+		}
+	}
+
+	return json.Marshal(fields)
+}
+
+// unmarshalExtra returns the fields of data that marshalling v, the struct decoded from
+// data, does not reproduce. These are the fields without a json tag and the tagged fields
+// whose value omitempty would drop, such as null, "" or [], so that they round-trip.
+func unmarshalExtra(data []byte, v interface{}) (map[string]interface{}, error) {
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var typed map[string]interface{}
+	if err := json.Unmarshal(b, &typed); err != nil {
+		return nil, err
+	}
+
+	for key := range typed {
+		delete(fields, key)
+	}
+
+	if len(fields) == 0 {
+		return nil, nil
+	}
+	return fields, nil
+}
+
+// RuleOperationBuilder builds a RuleOperation.
+//
+//	operation := NewRuleOperationBuilder().
+//		When(RuleFilterAll(RuleCompare("queries.query0.total", ">", 0))).
+//		SetProperty("alertLevel", "HIGH").
+//		CreateAlert().
+//		Build()
+type RuleOperationBuilder struct {
+	operation RuleOperation
+}
+
+// NewRuleOperationBuilder returns a builder for an operation without a condition or actions.
+func NewRuleOperationBuilder() *RuleOperationBuilder {
+	return &RuleOperationBuilder{}
+}
+
+// RuleCompare returns a comparison for use in RuleFilterAll and RuleFilterAny,
+// such as RuleCompare("queries.query0.total", ">", 0).
+func RuleCompare(left string, operator string, right interface{}) []interface{} {
+	return []interface{}{left, operator, right}
+}
+
+// RuleFilterAll returns a FILTER condition that matches when every comparison is true.
+func RuleFilterAll(comparisons ...[]interface{}) RuleCondition {
+	return ruleFilter("AND", comparisons)
+}
+
+// RuleFilterAny returns a FILTER condition that matches when any comparison is true.
+func RuleFilterAny(comparisons ...[]interface{}) RuleCondition {
+	return ruleFilter("OR", comparisons)
+}
+
+func ruleFilter(operator string, comparisons [][]interface{}) RuleCondition {
+	condition := []interface{}{operator}
+	for _, comparison := range comparisons {
+		condition = append(condition, comparison)
+	}
+	return RuleCondition{Type: "FILTER", SpecVersion: 1, Condition: condition}
+}
+
+// When sets the condition of the operation.
+func (b *RuleOperationBuilder) When(condition RuleCondition) *RuleOperationBuilder {
+	b.operation.When = &condition
+	return b
+}
+
+// Action appends an action to the operation.
+func (b *RuleOperationBuilder) Action(action RuleAction) *RuleOperationBuilder {
+	b.operation.Actions = append(b.operation.Actions, action)
+	return b
+}
+
+// SetProperty appends a SET_PROPERTY action, typically used to set alertLevel.
+func (b *RuleOperationBuilder) SetProperty(property string, value interface{}) *RuleOperationBuilder {
+	return b.Action(RuleAction{Type: RuleActionSetProperty, TargetProperty: property, TargetValue: value})
+}
+
+// CreateAlert appends a CREATE_ALERT action.
+func (b *RuleOperationBuilder) CreateAlert() *RuleOperationBuilder {
+	return b.Action(RuleAction{Type: RuleActionCreateAlert})
+}
+
+// SendEmail appends a SEND_EMAIL action.
+func (b *RuleOperationBuilder) SendEmail(recipients []string, body string) *RuleOperationBuilder {
+	return b.Action(RuleAction{Type: RuleActionSendEmail, Recipients: recipients, Body: body})
+}
+
+// SendSlackMessage appends a SEND_SLACK_MESSAGE action sent through the Slack
+// integration instance with the given id.
+func (b *RuleOperationBuilder) SendSlackMessage(integrationInstanceID string, channels []string, body string) *RuleOperationBuilder {
+	return b.Action(RuleAction{
+		Type:                  RuleActionSendSlackMessage,
+		IntegrationInstanceID: integrationInstanceID,
+		Channels:              channels,
+		Body:                  body,
+	})
+}
+
+// Webhook appends a WEBHOOK action.
+func (b *RuleOperationBuilder) Webhook(method string, endpoint string, headers map[string]string, body interface{}) *RuleOperationBuilder {
+	return b.Action(RuleAction{
+		Type:     RuleActionWebhook,
+		Method:   method,
+		Endpoint: endpoint,
+		Headers:  headers,
+		Body:     body,
+	})
+}
+
+// TagEntities appends a TAG_ENTITIES action that tags the entities selected by
+// entities, such as "{{queries.query0.data}}".
+func (b *RuleOperationBuilder) TagEntities(entities string, tags ...RuleEntityTag) *RuleOperationBuilder {
+	return b.Action(RuleAction{Type: RuleActionTagEntities, Entities: entities, Tags: tags})
+}
+
+// CreateJiraTicket appends a CREATE_JIRA_TICKET action.
+func (b *RuleOperationBuilder) CreateJiraTicket(ticket RuleJiraTicket) *RuleOperationBuilder {
+	autoResolve := ticket.AutoResolve
+
+	return b.Action(RuleAction{
+		Type:                  RuleActionCreateJiraTicket,
+		IntegrationInstanceID: ticket.IntegrationInstanceID,
+		EntityClass:           ticket.EntityClass,
+		Summary:               ticket.Summary,
+		IssueType:             ticket.IssueType,
+		Project:               ticket.Project,
+		AdditionalFields:      ticket.AdditionalFields,
+		AutoResolve:           &autoResolve,
+		ResolvedStatus:        ticket.ResolvedStatus,
+	})
+}
+
+// Build returns the operation.
+func (b *RuleOperationBuilder) Build() RuleOperation {
+	return b.operation
+}
@@ -0,0 +1,419 @@
+package jupiterone
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testRulePayload = map[string]interface{}{
+	"id":                  "rule-1",
+	"name":                "Unencrypted buckets",
+	"version":             2,
+	"specVersion":         1,
+	"latest":              true,
+	"type":                "QUESTION",
+	"pollingInterval":     "ONE_DAY",
+	"tags":                []interface{}{"aws"},
+	"notifyOnFailure":     true,
+	"questionName":        "unencrypted-buckets",
+	"lastEvaluationEndOn": 1700000000000,
+	"operations": []interface{}{map[string]interface{}{
+		"when": map[string]interface{}{
+			"type":        "FILTER",
+			"specVersion": 1,
+			"condition":   []interface{}{"AND", []interface{}{"queries.buckets.total", ">", 0}},
+		},
+		"actions": []interface{}{
+			map[string]interface{}{"id": "action-1", "type": "SET_PROPERTY", "targetProperty": "alertLevel", "targetValue": "HIGH"},
+			map[string]interface{}{"id": "action-2", "type": "CREATE_ALERT"},
+			map[string]interface{}{
+				"id":                    "action-3",
+				"type":                  "CREATE_JIRA_TICKET",
+				"integrationInstanceId": "jira-1",
+				"entityClass":           "Finding",
+				"summary":               "Unencrypted bucket",
+				"issueType":             "Bug",
+				"project":               "SEC",
+				"autoResolve":           false,
+				"additionalFields":      map[string]interface{}{"labels": []interface{}{"aws"}},
+			},
+			map[string]interface{}{
+				"id":         "action-4",
+				"type":       "SEND_MICROSOFT_TEAMS_MESSAGE",
+				"webhookUrl": "https://example.com/hook",
+				"body":       "{{alertWebLink}}",
+			},
+		},
+	}},
+	"outputs": []interface{}{"queries.buckets.total", "alertLevel"},
+}
+
+func TestCreateReferencedRule(t *testing.T) {
+	var sent map[string]interface{}
+
+	client := newGraphQLTestClient(t, graphQLHandler{
+		"CreateReferencedQuestionRuleInstance": func(vars map[string]interface{}) interface{} {
+			sent = vars["instance"].(map[string]interface{})
+			return map[string]interface{}{"createReferencedQuestionRuleInstance": testRulePayload}
+		},
+	})
+
+	_, err := client.Rule.CreateReferenced(CreateReferencedQuestionRuleInstanceInput{})
+	assert.ErrorIs(t, err, ErrInvalidRuleQuestionReference)
+
+	rule, err := client.Rule.CreateReferenced(CreateReferencedQuestionRuleInstanceInput{
+		QuestionName: "unencrypted-buckets",
+		RuleInstanceInputProperties: RuleInstanceInputProperties{
+			Name:            "Unencrypted buckets",
+			SpecVersion:     1,
+			PollingInterval: "ONE_DAY",
+			Tags:            []string{"aws"},
+			NotifyOnFailure: Bool(true),
+			Operations:      []RuleOperation{NewRuleOperationBuilder().CreateAlert().Build()},
+		},
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, "unencrypted-buckets", sent["questionName"])
+	assert.Equal(t, []interface{}{"aws"}, sent["tags"])
+	assert.Equal(t, true, sent["notifyOnFailure"])
+	assert.NotContains(t, sent, "questionId")
+	assert.Equal(t, []interface{}{map[string]interface{}{
+		"when":    nil,
+		"actions": []interface{}{map[string]interface{}{"type": "CREATE_ALERT"}},
+	}}, sent["operations"])
+
+	assert.Equal(t, "rule-1", rule.ID)
+	assert.Equal(t, "Unencrypted buckets", rule.Name)
+	assert.Equal(t, []string{"aws"}, rule.Tags)
+	assert.True(t, rule.NotifyOnFailure)
+	assert.Equal(t, "unencrypted-buckets", rule.QuestionName)
+	assert.Equal(t, RuleActionCreateAlert, rule.Operations[0].Actions[1].Type)
+}
+
+// sentKeys returns the sorted keys of a mutation input sent to the fake API.
+func sentKeys(input map[string]interface{}) []string {
+	keys := make([]string, 0, len(input))
+	for key := range input {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func TestCreateInlineRule(t *testing.T) {
+	var sent map[string]interface{}
+
+	client := newGraphQLTestClient(t, graphQLHandler{
+		"CreateInlineQuestionRuleInstance": func(vars map[string]interface{}) interface{} {
+			sent = vars["instance"].(map[string]interface{})
+			return map[string]interface{}{"createInlineQuestionRuleInstance": testRulePayload}
+		},
+	})
+
+	rule, err := client.Rule.CreateInline(CreateInlineQuestionRuleInstanceInput{
+		RuleInstanceInputProperties: RuleInstanceInputProperties{
+			Name:        "Unencrypted buckets",
+			SpecVersion: 1,
+			Operations:  []RuleOperation{NewRuleOperationBuilder().CreateAlert().Build()},
+		},
+		Question: RuleQuestion{Queries: []QuestionQuery{{Name: "buckets", Query: "FIND aws_s3_bucket", Version: "v1"}}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "rule-1", rule.ID)
+
+	assert.Equal(t, []string{
+		"name", "operations", "question", "specVersion",
+	}, sentKeys(sent))
+	assert.Equal(t, map[string]interface{}{"queries": []interface{}{
+		map[string]interface{}{"name": "buckets", "query": "FIND aws_s3_bucket", "version": "v1"},
+	}}, sent["question"])
+}
+
+func TestUpdateInlineRule(t *testing.T) {
+	var sent map[string]interface{}
+
+	client := newGraphQLTestClient(t, graphQLHandler{
+		"UpdateInlineQuestionRuleInstance": func(vars map[string]interface{}) interface{} {
+			sent = vars["instance"].(map[string]interface{})
+			return map[string]interface{}{"updateInlineQuestionRuleInstance": testRulePayload}
+		},
+	})
+
+	rule, err := client.Rule.UpdateInline(UpdateInlineQuestionRuleInstanceInput{
+		RuleInstanceUpdateProperties: RuleInstanceUpdateProperties{
+			ID:            "rule-1",
+			Version:       2,
+			State:         map[string]interface{}{"actions": []interface{}{}},
+			LatestAlertID: "alert-1",
+		},
+		RuleInstanceInputProperties: RuleInstanceInputProperties{
+			Name:                            "Unencrypted buckets",
+			Description:                     "Buckets without default encryption",
+			SpecVersion:                     1,
+			Operations:                      []RuleOperation{NewRuleOperationBuilder().CreateAlert().Build()},
+			Outputs:                         []string{"alertLevel"},
+			PollingInterval:                 "ONE_DAY",
+			Templates:                       map[string]interface{}{"name": "value"},
+			Tags:                            []string{"aws"},
+			NotifyOnFailure:                 Bool(false),
+			TriggerActionsOnNewEntitiesOnly: Bool(true),
+		},
+		Question: RuleQuestion{Queries: []QuestionQuery{{Query: "FIND aws_s3_bucket"}}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, rule.Version)
+	assert.Equal(t, int64(1700000000000), rule.LastEvaluationEndOn)
+
+	// Every field of UpdateInlineQuestionRuleInstanceInput in the schema, and nothing else.
+	assert.Equal(t, []string{
+		"description", "id", "latestAlertId", "name", "notifyOnFailure", "operations", "outputs",
+		"pollingInterval", "question", "specVersion", "state", "tags", "templates",
+		"triggerActionsOnNewEntitiesOnly", "version",
+	}, sentKeys(sent))
+	assert.Equal(t, "rule-1", sent["id"])
+	assert.Equal(t, float64(2), sent["version"])
+	assert.Equal(t, map[string]interface{}{"actions": []interface{}{}}, sent["state"])
+	assert.Equal(t, "alert-1", sent["latestAlertId"])
+	assert.Equal(t, false, sent["notifyOnFailure"])
+	assert.Equal(t, true, sent["triggerActionsOnNewEntitiesOnly"])
+}
+
+func TestUpdateReferencedRule(t *testing.T) {
+	var sent map[string]interface{}
+
+	client := newGraphQLTestClient(t, graphQLHandler{
+		"UpdateReferencedQuestionRuleInstance": func(vars map[string]interface{}) interface{} {
+			sent = vars["instance"].(map[string]interface{})
+			return map[string]interface{}{"updateReferencedQuestionRuleInstance": testRulePayload}
+		},
+	})
+
+	_, err := client.Rule.UpdateReferenced(UpdateReferencedQuestionRuleInstanceInput{QuestionID: "q-1", QuestionName: "q"})
+	assert.ErrorIs(t, err, ErrInvalidRuleQuestionReference)
+	assert.Nil(t, sent)
+
+	_, err = client.Rule.UpdateReferenced(UpdateReferencedQuestionRuleInstanceInput{
+		RuleInstanceUpdateProperties: RuleInstanceUpdateProperties{ID: "rule-1", Version: 2},
+		RuleInstanceInputProperties: RuleInstanceInputProperties{
+			Name:        "Unencrypted buckets",
+			SpecVersion: 1,
+			Operations:  []RuleOperation{NewRuleOperationBuilder().CreateAlert().Build()},
+		},
+		QuestionID: "q-1",
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"id", "name", "operations", "questionId", "specVersion", "version",
+	}, sentKeys(sent), "unset booleans are left out so the update keeps them")
+	assert.Equal(t, "q-1", sent["questionId"])
+}
+
+func TestRuleOperationsRoundTrip(t *testing.T) {
+	client := newGraphQLTestClient(t, graphQLHandler{
+		"GetQuestionRuleInstance": func(vars map[string]interface{}) interface{} {
+			return map[string]interface{}{"questionRuleInstance": testRulePayload}
+		},
+	})
+
+	rule, err := client.Rule.GetByID("rule-1")
+	assert.NoError(t, err)
+
+	operation := rule.Operations[0]
+	assert.Equal(t, "FILTER", operation.When.Type)
+	assert.Equal(t, RuleActionSetProperty, operation.Actions[0].Type)
+	assert.Equal(t, "alertLevel", operation.Actions[0].TargetProperty)
+	assert.Equal(t, "jira-1", operation.Actions[2].IntegrationInstanceID)
+	assert.Equal(t, false, *operation.Actions[2].AutoResolve)
+	assert.Equal(t, "https://example.com/hook", operation.Actions[3].Extra["webhookUrl"])
+
+	want, err := json.Marshal(testRulePayload["operations"])
+	assert.NoError(t, err)
+	got, err := json.Marshal(rule.Operations)
+	assert.NoError(t, err)
+	assert.JSONEq(t, string(want), string(got))
+}
+
+func TestRuleOperationsRoundTripZeroValues(t *testing.T) {
+	payload := `[
+		{
+			"when": {"type": "FILTER", "specVersion": 0, "condition": null},
+			"actions": [
+				{"type": "SET_PROPERTY", "id": "", "targetProperty": "alertLevel", "targetValue": null},
+				{"type": "SEND_EMAIL", "recipients": [], "body": ""},
+				{"type": "WEBHOOK", "endpoint": "https://example.com", "method": "POST", "headers": {}, "body": {}},
+				{"type": "CREATE_JIRA_TICKET", "summary": "", "autoResolve": false, "additionalFields": {}}
+			]
+		},
+		{"when": null, "actions": [{"type": "CREATE_ALERT"}]}
+	]`
+
+	var operations []RuleOperation
+	assert.NoError(t, json.Unmarshal([]byte(payload), &operations))
+
+	b, err := json.Marshal(operations)
+	assert.NoError(t, err)
+	assert.JSONEq(t, payload, string(b))
+
+	// A typed field set after decoding takes precedence over the kept zero value.
+	operations[0].Actions[1].Recipients = []string{"security@example.com"}
+	b, err = json.Marshal(operations[0].Actions[1])
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"type": "SEND_EMAIL", "recipients": ["security@example.com"], "body": ""}`, string(b))
+}
+
+func TestRuleVersionsMismatchedVersion(t *testing.T) {
+	var requested []interface{}
+
+	client := newGraphQLTestClient(t, graphQLHandler{
+		"GetQuestionRuleInstance": func(vars map[string]interface{}) interface{} {
+			requested = append(requested, vars["version"])
+
+			// The latest version is returned whichever version is asked for.
+			return map[string]interface{}{"questionRuleInstance": map[string]interface{}{"id": "rule-1", "version": 3, "latest": true}}
+		},
+	})
+
+	versions, err := client.Rule.Versions(context.Background(), "rule-1").All()
+	assert.NoError(t, err)
+
+	assert.Equal(t, []interface{}{nil, float64(2), float64(1)}, requested)
+	if assert.Len(t, versions, 1) {
+		assert.Equal(t, 3, versions[0].Version)
+	}
+}
+
+func TestGetRuleNotFound(t *testing.T) {
+	client := newGraphQLTestClient(t, graphQLHandler{
+		"GetQuestionRuleInstance": func(vars map[string]interface{}) interface{} {
+			return map[string]interface{}{"questionRuleInstance": nil}
+		},
+	})
+
+	rule, err := client.Rule.GetByID("missing")
+	assert.ErrorIs(t, err, ErrRuleInstanceNotFound)
+	assert.Nil(t, rule)
+
+	_, err = client.Rule.Versions(context.Background(), "missing").All()
+	assert.ErrorIs(t, err, ErrRuleInstanceNotFound)
+}
+
+func TestRuleOperationBuilder(t *testing.T) {
+	operation := NewRuleOperationBuilder().
+		When(RuleFilterAny(
+			RuleCompare("queries.buckets.total", ">", 0),
+			RuleCompare("queries.keys.total", ">", 10),
+		)).
+		SetProperty("alertLevel", "CRITICAL").
+		CreateAlert().
+		SendEmail([]string{"security@example.com"}, "{{alertWebLink}}").
+		SendSlackMessage("slack-1", []string{"#alerts"}, "{{alertWebLink}}").
+		Webhook("POST", "https://example.com/hook", map[string]string{"Authorization": "Bearer token"}, map[string]interface{}{"rule": "{{ruleName}}"}).
+		TagEntities("{{queries.buckets.data}}", RuleEntityTag{Name: "unencrypted", Value: true}).
+		CreateJiraTicket(RuleJiraTicket{IntegrationInstanceID: "jira-1", EntityClass: "Finding", Summary: "Unencrypted bucket", IssueType: "Bug", Project: "SEC"}).
+		Build()
+
+	b, err := json.Marshal(operation)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"when": {
+			"type": "FILTER",
+			"specVersion": 1,
+			"condition": ["OR", ["queries.buckets.total", ">", 0], ["queries.keys.total", ">", 10]]
+		},
+		"actions": [
+			{"type": "SET_PROPERTY", "targetProperty": "alertLevel", "targetValue": "CRITICAL"},
+			{"type": "CREATE_ALERT"},
+			{"type": "SEND_EMAIL", "recipients": ["security@example.com"], "body": "{{alertWebLink}}"},
+			{"type": "SEND_SLACK_MESSAGE", "integrationInstanceId": "slack-1", "channels": ["#alerts"], "body": "{{alertWebLink}}"},
+			{
+				"type": "WEBHOOK",
+				"method": "POST",
+				"endpoint": "https://example.com/hook",
+				"headers": {"Authorization": "Bearer token"},
+				"body": {"rule": "{{ruleName}}"}
+			},
+			{"type": "TAG_ENTITIES", "entities": "{{queries.buckets.data}}", "tags": [{"name": "unencrypted", "value": true}]},
+			{
+				"type": "CREATE_JIRA_TICKET",
+				"integrationInstanceId": "jira-1",
+				"entityClass": "Finding",
+				"summary": "Unencrypted bucket",
+				"issueType": "Bug",
+				"project": "SEC",
+				"autoResolve": false
+			}
+		]
+	}`, string(b))
+}
+
+func TestRulesIterator(t *testing.T) {
+	var filters []interface{}
+
+	client := newGraphQLTestClient(t, graphQLHandler{
+		"ListRuleInstances": func(vars map[string]interface{}) interface{} {
+			filters = append(filters, vars["filters"])
+
+			if vars["cursor"] == nil {
+				return map[string]interface{}{"listRuleInstances": map[string]interface{}{
+					"questionInstances": []interface{}{testRulePayload},
+					"reportInstances":   []interface{}{map[string]interface{}{"id": "report-1", "type": "REPORT", "operations": []interface{}{}}},
+					"pageInfo":          map[string]interface{}{"endCursor": "page-2", "hasNextPage": true},
+				}}
+			}
+
+			return map[string]interface{}{"listRuleInstances": map[string]interface{}{
+				"questionInstances": []interface{}{map[string]interface{}{"id": "rule-2", "operations": []interface{}{}}},
+				"reportInstances":   []interface{}{},
+				"pageInfo":          map[string]interface{}{"hasNextPage": false},
+			}}
+		},
+	})
+
+	rules, err := client.Rule.RulesIterator(context.Background(), ListRulesFilter{Tags: []string{"aws"}}).All()
+	assert.NoError(t, err)
+
+	assert.Len(t, rules, 3)
+	assert.Equal(t, "rule-1", rules[0].Question.ID)
+	assert.Equal(t, "report-1", rules[1].Report.ID)
+	assert.Nil(t, rules[1].Question)
+	assert.Equal(t, "rule-2", rules[2].Question.ID)
+
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"tags": []interface{}{"aws"}},
+		map[string]interface{}{"tags": []interface{}{"aws"}},
+	}, filters)
+}
+
+func TestRuleVersions(t *testing.T) {
+	var requested []interface{}
+
+	client := newGraphQLTestClient(t, graphQLHandler{
+		"GetQuestionRuleInstance": func(vars map[string]interface{}) interface{} {
+			requested = append(requested, vars["version"])
+
+			switch vars["version"] {
+			case nil:
+				return map[string]interface{}{"questionRuleInstance": map[string]interface{}{"id": "rule-1", "version": 3, "latest": true}}
+			case float64(1):
+				return map[string]interface{}{"questionRuleInstance": map[string]interface{}{"id": "rule-1", "version": 1}}
+			default:
+				return map[string]interface{}{"questionRuleInstance": nil}
+			}
+		},
+	})
+
+	versions, err := client.Rule.Versions(context.Background(), "rule-1").All()
+	assert.NoError(t, err)
+
+	assert.Equal(t, []interface{}{nil, float64(2), float64(1)}, requested)
+	assert.Len(t, versions, 2)
+	assert.Equal(t, 3, versions[0].Version)
+	assert.True(t, versions[0].Latest)
+	assert.Equal(t, 1, versions[1].Version)
+}
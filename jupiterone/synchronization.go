@@ -2,7 +2,9 @@ package jupiterone
 
 import (
 	"bytes"
+	"compress/gzip"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -12,6 +14,24 @@ import (
 
 type SynchronizationService service
 
+// SyncError is returned when the synchronization API responds with a non-2xx status.
+// Code and Message are taken from the error body when the persister provides one.
+type SyncError struct {
+	StatusCode int
+	Code       string
+	Message    string
+}
+
+func (e *SyncError) Error() string {
+	if e.Code != "" {
+		return fmt.Sprintf("sync api error (%d %s): %s", e.StatusCode, e.Code, e.Message)
+	}
+	return fmt.Sprintf("sync api error (%d): %s", e.StatusCode, e.Message)
+}
+
+// maxSyncErrorBodySize caps how much of an error response body is read.
+const maxSyncErrorBodySize = 64 * 1024
+
 const (
 	syncAPIStartPath         = "%s/persister/synchronization/jobs"
 	syncAPIUploadPath        = "%s/persister/synchronization/jobs/%s/upload"
@@ -19,9 +39,17 @@ const (
 	syncAPIStatusPath        = "%s/persister/synchronization/jobs/%s"
 	syncAPIEntitiesPath      = "%s/persister/synchronization/jobs/%s/entities"
 	syncAPIRelationshipsPath = "%s/persister/synchronization/jobs/%s/relationships"
+
+	// syncChunkSize is the number of entities or relationships sent per upload.
+	syncChunkSize = 150
 )
 
+// Start starts a new sync job after validating params.
 func (s *SynchronizationService) Start(params domain.StartParams) (*domain.SynchronizationJobOutput, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
 	body, err := json.Marshal(params)
 	if err != nil {
 		return nil, err
@@ -49,23 +77,20 @@ func (s *SynchronizationService) Upload(id string, data domain.SyncPayload) (*do
 	if err != nil {
 		return nil, err
 	}
-	body := bytes.NewBuffer(dataAsBytes)
 
-	return s.syncHelper(url, http.MethodPost, body)
+	return s.uploadHelper(url, dataAsBytes)
 }
 
 func (s *SynchronizationService) UploadEntities(id string, data []byte) (*domain.SynchronizationJobOutput, error) {
 	url := fmt.Sprintf(syncAPIEntitiesPath, s.client.httpBaseURL, id)
-	body := bytes.NewBuffer(data)
 
-	return s.syncHelper(url, http.MethodPost, body)
+	return s.uploadHelper(url, data)
 }
 
 func (s *SynchronizationService) UploadRelationships(id string, data []byte) (*domain.SynchronizationJobOutput, error) {
 	url := fmt.Sprintf(syncAPIRelationshipsPath, s.client.httpBaseURL, id)
-	body := bytes.NewBuffer(data)
 
-	return s.syncHelper(url, http.MethodPost, body)
+	return s.uploadHelper(url, data)
 }
 
 func (s *SynchronizationService) marshalEntities(entities []interface{}) domain.SyncPayload {
@@ -83,12 +108,15 @@ func (s *SynchronizationService) marshalRelationships(relationships []interface{
 type chunkUploadFunctions struct {
 	marshalPayload func([]interface{}) domain.SyncPayload
 	upload         func(string, domain.SyncPayload) (*domain.SynchronizationJobOutput, error)
+	// chunkUploaded, if set, is called after every successful upload.
+	chunkUploaded func() error
 }
 
 // chunkUpload breaks apart the payload into chunks and uploads them so that the user
-// is protected from uploading data that is too large at one time.
+// is protected from uploading data that is too large at one time. A chunk that is
+// rejected with 413 Payload Too Large is split in half and retried.
 func (s *SynchronizationService) chunkUpload(jobID string, payloadItems []interface{}, fns chunkUploadFunctions) error {
-	interval := 150
+	interval := syncChunkSize
 
 	for len(payloadItems) != 0 {
 		if interval > len(payloadItems) {
@@ -96,18 +124,40 @@ func (s *SynchronizationService) chunkUpload(jobID string, payloadItems []interf
 		}
 
 		chunk := payloadItems[:interval]
-		syncPayload := fns.marshalPayload(chunk)
-		_, err := fns.upload(jobID, syncPayload)
+		err := s.uploadChunk(jobID, chunk, fns)
 		if err != nil {
 			return err
 		}
 
+		if fns.chunkUploaded != nil {
+			if err := fns.chunkUploaded(); err != nil {
+				return err
+			}
+		}
+
 		payloadItems = payloadItems[interval:]
 	}
 
 	return nil
 }
 
+// uploadChunk uploads chunk, recursively halving it for as long as the API
+// responds with 413 Payload Too Large and there is more than one item left.
+func (s *SynchronizationService) uploadChunk(jobID string, chunk []interface{}, fns chunkUploadFunctions) error {
+	_, err := fns.upload(jobID, fns.marshalPayload(chunk))
+
+	var syncErr *SyncError
+	if errors.As(err, &syncErr) && syncErr.StatusCode == http.StatusRequestEntityTooLarge && len(chunk) > 1 {
+		half := len(chunk) / 2
+		if err := s.uploadChunk(jobID, chunk[:half], fns); err != nil {
+			return err
+		}
+		return s.uploadChunk(jobID, chunk[half:], fns)
+	}
+
+	return err
+}
+
 // ProcessSyncJob is a helper function that will start, upload, and finalize a sync job.
 func (s *SynchronizationService) ProcessSyncJob(sp domain.StartParams, data domain.SyncPayload) (*domain.SynchronizationJobOutput, error) {
 	syncJob, err := s.Start(sp)
@@ -142,7 +192,41 @@ func (s *SynchronizationService) ProcessSyncJob(sp domain.StartParams, data doma
 	return s.Status(syncJob.ID)
 }
 
+// uploadHelper posts data to url, compressing it with gzip when the client is
+// configured with GzipUploads.
+func (s *SynchronizationService) uploadHelper(url string, data []byte) (*domain.SynchronizationJobOutput, error) {
+	if !s.client.gzipUploads {
+		return s.syncHelper(url, http.MethodPost, bytes.NewBuffer(data))
+	}
+
+	var body bytes.Buffer
+	zw := gzip.NewWriter(&body)
+	if _, err := zw.Write(data); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+
+	req, err := s.newSyncRequest(url, http.MethodPost, &body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Encoding", "gzip")
+
+	return s.doSyncRequest(req)
+}
+
 func (s *SynchronizationService) syncHelper(url string, method string, body io.Reader) (*domain.SynchronizationJobOutput, error) {
+	req, err := s.newSyncRequest(url, method, body)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.doSyncRequest(req)
+}
+
+func (s *SynchronizationService) newSyncRequest(url string, method string, body io.Reader) (*http.Request, error) {
 	req, err := http.NewRequest(method, url, body)
 	if err != nil {
 		return nil, err
@@ -151,12 +235,20 @@ func (s *SynchronizationService) syncHelper(url string, method string, body io.R
 	req = s.client.addAuthHeaders(req)
 	req.Header.Set("Content-Type", "application/json")
 
+	return req, nil
+}
+
+func (s *SynchronizationService) doSyncRequest(req *http.Request) (*domain.SynchronizationJobOutput, error) {
 	resp, err := s.client.httpClient.Do(req)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, newSyncError(resp)
+	}
+
 	syncJobOutput := struct {
 		SyncJobOutput *domain.SynchronizationJobOutput `json:"job"`
 	}{
@@ -171,3 +263,40 @@ func (s *SynchronizationService) syncHelper(url string, method string, body io.R
 
 	return syncJobOutput.SyncJobOutput, nil
 }
+
+// newSyncError builds a SyncError from a non-2xx response. The persister reports
+// errors either as {"error": {"code", "message"}} or as a top-level {"code", "message"};
+// anything else is surfaced as the raw body or the HTTP status text.
+func newSyncError(resp *http.Response) error {
+	syncErr := &SyncError{StatusCode: resp.StatusCode}
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxSyncErrorBodySize))
+
+	errorBody := struct {
+		Error *struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	}{}
+
+	if json.Unmarshal(body, &errorBody) == nil {
+		if errorBody.Error != nil {
+			syncErr.Code = errorBody.Error.Code
+			syncErr.Message = errorBody.Error.Message
+		} else {
+			syncErr.Code = errorBody.Code
+			syncErr.Message = errorBody.Message
+		}
+	}
+
+	if syncErr.Message == "" {
+		syncErr.Message = string(bytes.TrimSpace(body))
+	}
+	if syncErr.Message == "" {
+		syncErr.Message = http.StatusText(resp.StatusCode)
+	}
+
+	return syncErr
+}
@@ -0,0 +1,174 @@
+package jupiterone
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+
+	"github.com/jupiterone/jupiterone-client-go/jupiterone/domain"
+)
+
+// SyncCheckpointStore persists the progress of a resumable sync job.
+// Load should return a nil checkpoint and a nil error when nothing has been saved.
+type SyncCheckpointStore interface {
+	Load() (*domain.SyncCheckpoint, error)
+	Save(checkpoint *domain.SyncCheckpoint) error
+	Clear() error
+}
+
+// FileCheckpointStore is a SyncCheckpointStore that keeps the checkpoint
+// as JSON in a single file on disk.
+type FileCheckpointStore struct {
+	Path string
+}
+
+// NewFileCheckpointStore returns a FileCheckpointStore that writes to path.
+func NewFileCheckpointStore(path string) *FileCheckpointStore {
+	return &FileCheckpointStore{Path: path}
+}
+
+// Load reads the checkpoint from disk. A missing file is not an error.
+func (f *FileCheckpointStore) Load() (*domain.SyncCheckpoint, error) {
+	b, err := os.ReadFile(f.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var checkpoint domain.SyncCheckpoint
+	if err := json.Unmarshal(b, &checkpoint); err != nil {
+		return nil, err
+	}
+
+	return &checkpoint, nil
+}
+
+// Save writes the checkpoint to a temporary file and renames it into place
+// so that a crash mid-write never leaves a truncated checkpoint behind.
+func (f *FileCheckpointStore) Save(checkpoint *domain.SyncCheckpoint) error {
+	b, err := json.Marshal(checkpoint)
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(f.Path, b)
+}
+
+// Clear removes the checkpoint file. A missing file is not an error.
+func (f *FileCheckpointStore) Clear() error {
+	err := os.Remove(f.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// writeFileAtomic writes b to a temporary file next to path and renames it into place.
+func writeFileAtomic(path string, b []byte) error {
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o600); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, path)
+}
+
+// ProcessResumableSyncJob behaves like ProcessSyncJob but records its progress in store
+// after every uploaded chunk.
+//
+// If store already holds a checkpoint for a job that is still awaiting uploads, that job
+// is reattached and uploading continues from the next chunk. A checkpoint for a job that
+// can no longer accept uploads is discarded and a new job is started. The caller must pass
+// the same data on every attempt so that chunk boundaries line up.
+//
+// The checkpoint is cleared once the job has been finalized.
+func (s *SynchronizationService) ProcessResumableSyncJob(sp domain.StartParams, data domain.SyncPayload, store SyncCheckpointStore) (*domain.SynchronizationJobOutput, error) {
+	checkpoint, err := s.resumeCheckpoint(store)
+	if err != nil {
+		return nil, err
+	}
+
+	if checkpoint == nil {
+		syncJob, err := s.Start(sp)
+		if err != nil {
+			return nil, err
+		}
+
+		checkpoint = &domain.SyncCheckpoint{JobID: syncJob.ID}
+		if err := store.Save(checkpoint); err != nil {
+			return nil, err
+		}
+	}
+
+	entityChunkUploadFunctions := chunkUploadFunctions{
+		marshalPayload: s.marshalEntities,
+		upload:         s.Upload,
+		chunkUploaded: func() error {
+			checkpoint.EntityChunksUploaded++
+			return store.Save(checkpoint)
+		},
+	}
+
+	entities := skipChunks(data.Entities, checkpoint.EntityChunksUploaded)
+	err = s.chunkUpload(checkpoint.JobID, entities, entityChunkUploadFunctions)
+	if err != nil {
+		return nil, err
+	}
+
+	relationshipChunkUploadFunctions := chunkUploadFunctions{
+		marshalPayload: s.marshalRelationships,
+		upload:         s.Upload,
+		chunkUploaded: func() error {
+			checkpoint.RelationshipChunksUploaded++
+			return store.Save(checkpoint)
+		},
+	}
+
+	relationships := skipChunks(data.Relationships, checkpoint.RelationshipChunksUploaded)
+	err = s.chunkUpload(checkpoint.JobID, relationships, relationshipChunkUploadFunctions)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.Finalize(checkpoint.JobID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := store.Clear(); err != nil {
+		return nil, err
+	}
+
+	return s.Status(checkpoint.JobID)
+}
+
+// resumeCheckpoint loads the stored checkpoint and returns it only if its job
+// is still open for uploads. Stale checkpoints are cleared.
+func (s *SynchronizationService) resumeCheckpoint(store SyncCheckpointStore) (*domain.SyncCheckpoint, error) {
+	checkpoint, err := store.Load()
+	if err != nil || checkpoint == nil {
+		return nil, err
+	}
+
+	job, err := s.Status(checkpoint.JobID)
+	if err != nil {
+		return nil, err
+	}
+
+	if job.Status != domain.SyncJobStatusAwaitingUploads {
+		return nil, store.Clear()
+	}
+
+	return checkpoint, nil
+}
+
+// skipChunks drops the items that were already sent in the first n chunks.
+func skipChunks(payloadItems []interface{}, n int) []interface{} {
+	skip := n * syncChunkSize
+	if skip > len(payloadItems) {
+		skip = len(payloadItems)
+	}
+	return payloadItems[skip:]
+}
@@ -0,0 +1,241 @@
+package jupiterone
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jupiterone/jupiterone-client-go/jupiterone/domain"
+)
+
+var ErrMissingKey = errors.New("sync payload item has no _key")
+
+const (
+	planEntitiesQuery      = `FIND * WITH _scope = %s`
+	planRelationshipsQuery = `FIND * THAT RELATES TO AS r * WHERE r._scope = %s RETURN TREE`
+)
+
+// Plan computes what a DIFF sync of data into scope would create, update and delete
+// without starting a job. The existing entities and relationships in scope are read
+// through the Query service and matched to the payload by _key.
+//
+// Only the non-metadata properties (those not prefixed with an underscore) of each payload
+// item are compared. Properties the graph has but the payload does not, such as those
+// JupiterOne adds itself like displayName, are ignored, so a property dropped from the
+// payload is not reported as removed.
+func (s *SynchronizationService) Plan(scope string, data domain.SyncPayload) (*domain.SyncPlan, error) {
+	existingEntities, err := s.existingEntities(scope)
+	if err != nil {
+		return nil, err
+	}
+
+	entities, err := diffSyncItems(data.Entities, existingEntities)
+	if err != nil {
+		return nil, err
+	}
+
+	existingRelationships, err := s.existingRelationships(scope)
+	if err != nil {
+		return nil, err
+	}
+
+	relationships, err := diffSyncItems(data.Relationships, existingRelationships)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.SyncPlan{
+		Scope:         scope,
+		Entities:      entities,
+		Relationships: relationships,
+	}, nil
+}
+
+func (s *SynchronizationService) existingEntities(scope string) (map[string]domain.SyncPlanItem, error) {
+	items := map[string]domain.SyncPlanItem{}
+	cursor := ""
+
+	for {
+		results, err := s.client.Query.Query(QueryInput{
+			Query:  fmt.Sprintf(planEntitiesQuery, strconv.Quote(scope)),
+			Cursor: cursor,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		list, err := s.client.Query.AsList(results)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, vertex := range list.Data {
+			item := domain.SyncPlanItem{
+				Key:        vertex.Entity.Key,
+				Properties: withoutMetadata(vertex.Properties),
+			}
+			if len(vertex.Entity.Type) > 0 {
+				item.Type = vertex.Entity.Type[0]
+			}
+			items[item.Key] = item
+		}
+
+		if list.Cursor == "" {
+			return items, nil
+		}
+		cursor = list.Cursor
+	}
+}
+
+func (s *SynchronizationService) existingRelationships(scope string) (map[string]domain.SyncPlanItem, error) {
+	items := map[string]domain.SyncPlanItem{}
+	cursor := ""
+
+	for {
+		results, err := s.client.Query.Query(QueryInput{
+			Query:  fmt.Sprintf(planRelationshipsQuery, strconv.Quote(scope)),
+			Cursor: cursor,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		tree, err := s.client.Query.AsTree(results)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, edge := range tree.Data.Edges {
+			properties, _ := edge.Properties.(map[string]interface{})
+			items[edge.Relationship.Key] = domain.SyncPlanItem{
+				Key:        edge.Relationship.Key,
+				Type:       edge.Relationship.Type,
+				Properties: withoutMetadata(properties),
+			}
+		}
+
+		if tree.Cursor == "" {
+			return items, nil
+		}
+		cursor = tree.Cursor
+	}
+}
+
+// diffSyncItems compares the desired payload items against the existing items keyed by _key.
+func diffSyncItems(desired []interface{}, existing map[string]domain.SyncPlanItem) (domain.SyncPlanChanges, error) {
+	var changes domain.SyncPlanChanges
+	seen := map[string]bool{}
+
+	for _, payloadItem := range desired {
+		item, err := toSyncPlanItem(payloadItem)
+		if err != nil {
+			return changes, err
+		}
+		seen[item.Key] = true
+
+		current, ok := existing[item.Key]
+		if !ok {
+			changes.Creates = append(changes.Creates, item)
+			continue
+		}
+
+		// Only the properties the payload sets are compared; see Plan.
+		currentProperties := make(map[string]interface{}, len(item.Properties))
+		for name := range item.Properties {
+			if value, ok := current.Properties[name]; ok {
+				currentProperties[name] = value
+			}
+		}
+
+		propertyChanges := diffProperties(currentProperties, item.Properties)
+		if len(propertyChanges) > 0 {
+			changes.Updates = append(changes.Updates, domain.SyncPlanUpdate{
+				Key:     item.Key,
+				Type:    item.Type,
+				Changes: propertyChanges,
+			})
+		}
+	}
+
+	for key, item := range existing {
+		if !seen[key] {
+			changes.Deletes = append(changes.Deletes, item)
+		}
+	}
+
+	sort.Slice(changes.Creates, func(i, j int) bool { return changes.Creates[i].Key < changes.Creates[j].Key })
+	sort.Slice(changes.Updates, func(i, j int) bool { return changes.Updates[i].Key < changes.Updates[j].Key })
+	sort.Slice(changes.Deletes, func(i, j int) bool { return changes.Deletes[i].Key < changes.Deletes[j].Key })
+
+	return changes, nil
+}
+
+// toSyncPlanItem normalizes a payload item through JSON so that it can be
+// compared with the values returned by the graph.
+func toSyncPlanItem(payloadItem interface{}) (domain.SyncPlanItem, error) {
+	var item domain.SyncPlanItem
+
+	b, err := json.Marshal(payloadItem)
+	if err != nil {
+		return item, err
+	}
+
+	var raw map[string]interface{}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return item, err
+	}
+
+	key, _ := raw["_key"].(string)
+	if key == "" {
+		return item, ErrMissingKey
+	}
+
+	item.Key = key
+	item.Type, _ = raw["_type"].(string)
+	item.Properties = withoutMetadata(raw)
+
+	return item, nil
+}
+
+func diffProperties(old, new map[string]interface{}) []domain.PropertyChange {
+	names := map[string]bool{}
+	for name := range old {
+		names[name] = true
+	}
+	for name := range new {
+		names[name] = true
+	}
+
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	var changes []domain.PropertyChange
+	for _, name := range sorted {
+		if !reflect.DeepEqual(old[name], new[name]) {
+			changes = append(changes, domain.PropertyChange{
+				Name: name,
+				Old:  old[name],
+				New:  new[name],
+			})
+		}
+	}
+
+	return changes
+}
+
+func withoutMetadata(properties map[string]interface{}) map[string]interface{} {
+	filtered := map[string]interface{}{}
+	for name, value := range properties {
+		if !strings.HasPrefix(name, "_") {
+			filtered[name] = value
+		}
+	}
+	return filtered
+}
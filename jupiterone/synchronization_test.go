@@ -1,10 +1,23 @@
 package jupiterone
 
 import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/jupiterone/jupiterone-client-go/jupiterone/domain"
+	"github.com/stretchr/testify/assert"
 )
 
 type syncTests struct {
@@ -70,3 +83,361 @@ func TestChunkUpload(t *testing.T) {
 		}
 	}
 }
+
+// fakePersister is a minimal in-memory stand-in for the synchronization API.
+type fakePersister struct {
+	mu        sync.Mutex
+	starts    int
+	uploads   int
+	status    domain.SyncJobStatus
+	abortAt   int
+	finalized bool
+}
+
+func (f *fakePersister) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch {
+	case r.URL.Path == "/persister/synchronization/jobs":
+		f.starts++
+		f.status = domain.SyncJobStatusAwaitingUploads
+	case strings.HasSuffix(r.URL.Path, "/upload"):
+		f.uploads++
+		if f.uploads == f.abortAt {
+			panic(http.ErrAbortHandler)
+		}
+	case strings.HasSuffix(r.URL.Path, "/finalize"):
+		f.finalized = true
+		f.status = domain.SyncJobStatusFinished
+	}
+
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"job": map[string]interface{}{"id": "job-1", "status": f.status},
+	})
+}
+
+func newTestClient(t *testing.T, handler http.Handler) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(&Config{APIKey: "a", AccountID: "a"})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	client.httpBaseURL = server.URL
+
+	return client
+}
+
+func TestProcessResumableSyncJob(t *testing.T) {
+	persister := &fakePersister{abortAt: 3}
+	client := newTestClient(t, persister)
+	store := NewFileCheckpointStore(filepath.Join(t.TempDir(), "checkpoint.json"))
+
+	data := domain.SyncPayload{
+		Entities:      createFakeData(400),
+		Relationships: createFakeData(200),
+	}
+
+	_, err := client.Synchronization.ProcessResumableSyncJob(domain.NewScopedDiffStartParams("test"), data, store)
+	assert.Error(t, err, "third upload should fail")
+
+	checkpoint, err := store.Load()
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.SyncCheckpoint{JobID: "job-1", EntityChunksUploaded: 2}, checkpoint)
+
+	output, err := client.Synchronization.ProcessResumableSyncJob(domain.NewScopedDiffStartParams("test"), data, store)
+	assert.NoError(t, err)
+	assert.Equal(t, "job-1", output.ID)
+	assert.Equal(t, 1, persister.starts, "job should be reattached rather than restarted")
+	assert.Equal(t, 6, persister.uploads, "only the remaining chunks should be uploaded")
+	assert.True(t, persister.finalized)
+
+	checkpoint, err = store.Load()
+	assert.NoError(t, err)
+	assert.Nil(t, checkpoint, "checkpoint should be cleared after finalize")
+}
+
+func TestStartParamsValidate(t *testing.T) {
+	tests := []struct {
+		title  string
+		params domain.StartParams
+		valid  bool
+	}{
+		{
+			title:  "scoped diff",
+			params: domain.NewScopedDiffStartParams("my-scope"),
+			valid:  true,
+		},
+		{
+			title:  "instance diff",
+			params: domain.StartParams{Source: domain.SyncSourceIntegrationManaged, InstanceID: "abc", SyncMode: domain.SyncModeDiff},
+			valid:  true,
+		},
+		{
+			title:  "create or update without scope",
+			params: domain.StartParams{Source: domain.SyncSourceAPI, SyncMode: domain.SyncModeCreateOrUpdate},
+			valid:  true,
+		},
+		{
+			title:  "diff without scope or instance",
+			params: domain.StartParams{Source: domain.SyncSourceAPI, SyncMode: domain.SyncModeDiff},
+		},
+		{
+			title:  "default mode without scope",
+			params: domain.StartParams{Source: domain.SyncSourceAPI},
+		},
+		{
+			title:  "diff with scope and instance",
+			params: domain.StartParams{Scope: "my-scope", InstanceID: "abc", SyncMode: domain.SyncModeDiff},
+		},
+		{
+			title:  "misspelled mode",
+			params: domain.StartParams{Scope: "my-scope", SyncMode: "CREATE_OR_UPDAET"},
+		},
+		{
+			title:  "integration managed without instance",
+			params: domain.StartParams{Source: domain.SyncSourceIntegrationManaged, SyncMode: domain.SyncModeCreateOrUpdate},
+		},
+	}
+
+	for _, tv := range tests {
+		err := tv.params.Validate()
+		if tv.valid {
+			assert.NoError(t, err, tv.title)
+		} else {
+			assert.ErrorIs(t, err, domain.ErrInvalidStartParams, tv.title)
+		}
+	}
+}
+
+// fakeQueryService returns canned results keyed by the start of the J1QL query.
+type fakeQueryService struct {
+	QueryService
+	results map[string]interface{}
+}
+
+func (f *fakeQueryService) Query(qi QueryInput) (interface{}, error) {
+	for prefix, result := range f.results {
+		if strings.HasPrefix(qi.Query, prefix) {
+			return result, nil
+		}
+	}
+	return nil, fmt.Errorf("unexpected query %q", qi.Query)
+}
+
+func TestPlan(t *testing.T) {
+	client, err := NewClient(&Config{APIKey: "a", AccountID: "a"})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	client.Query = &fakeQueryService{
+		results: map[string]interface{}{
+			"FIND * WITH": map[string]interface{}{
+				"type": "list",
+				"data": []interface{}{
+					map[string]interface{}{
+						"entity":     map[string]interface{}{"_key": "unchanged", "_type": []interface{}{"t"}},
+						"properties": map[string]interface{}{"name": "a", "displayName": "A", "_scope": "s"},
+					},
+					map[string]interface{}{
+						"entity":     map[string]interface{}{"_key": "changed", "_type": []interface{}{"t"}},
+						"properties": map[string]interface{}{"name": "b", "count": 1.0, "stale": true},
+					},
+					map[string]interface{}{
+						"entity":     map[string]interface{}{"_key": "removed", "_type": []interface{}{"t"}},
+						"properties": map[string]interface{}{},
+					},
+				},
+			},
+			"FIND * THAT": map[string]interface{}{
+				"type": "tree",
+				"data": map[string]interface{}{
+					"edges": []interface{}{
+						map[string]interface{}{
+							"relationship": map[string]interface{}{"_key": "old-rel", "_type": "t_has_t"},
+							"properties":   map[string]interface{}{"_scope": "s"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	payload := domain.SyncPayload{
+		Entities: []interface{}{
+			map[string]interface{}{"_key": "unchanged", "_type": "t", "name": "a"},
+			map[string]interface{}{"_key": "changed", "_type": "t", "name": "b", "count": 2, "added": "x"},
+			map[string]interface{}{"_key": "new", "_type": "t"},
+		},
+		Relationships: []interface{}{
+			map[string]interface{}{"_key": "new-rel", "_type": "t_has_t"},
+		},
+	}
+
+	plan, err := client.Synchronization.Plan("s", payload)
+	assert.NoError(t, err)
+
+	// Properties only the graph has, like displayName and stale, are not reported as removed.
+	assert.Equal(t, []domain.SyncPlanItem{{Key: "new", Type: "t", Properties: map[string]interface{}{}}}, plan.Entities.Creates)
+	assert.Equal(t, []domain.SyncPlanUpdate{{
+		Key:  "changed",
+		Type: "t",
+		Changes: []domain.PropertyChange{
+			{Name: "added", Old: nil, New: "x"},
+			{Name: "count", Old: 1.0, New: 2.0},
+		},
+	}}, plan.Entities.Updates)
+	assert.Equal(t, []domain.SyncPlanItem{{Key: "removed", Type: "t", Properties: map[string]interface{}{}}}, plan.Entities.Deletes)
+
+	assert.Equal(t, "new-rel", plan.Relationships.Creates[0].Key)
+	assert.Equal(t, "old-rel", plan.Relationships.Deletes[0].Key)
+	assert.False(t, plan.IsEmpty())
+}
+
+func TestSyncHelperReturnsSyncError(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"error":{"code":"UNAUTHORIZED","message":"bad token"}}`))
+	}))
+
+	_, err := client.Synchronization.Status("job-1")
+
+	var syncErr *SyncError
+	assert.True(t, errors.As(err, &syncErr))
+	assert.Equal(t, http.StatusUnauthorized, syncErr.StatusCode)
+	assert.Equal(t, "UNAUTHORIZED", syncErr.Code)
+	assert.Equal(t, "bad token", syncErr.Message)
+}
+
+func TestChunkUploadRechunksPayloadTooLarge(t *testing.T) {
+	var mu sync.Mutex
+	uploaded := 0
+
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload domain.SyncPayload
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+
+		if len(payload.Entities) > 40 {
+			w.WriteHeader(http.StatusRequestEntityTooLarge)
+			return
+		}
+
+		mu.Lock()
+		uploaded += len(payload.Entities)
+		mu.Unlock()
+		_, _ = w.Write([]byte(`{"job":{"id":"job-1"}}`))
+	}))
+
+	fns := chunkUploadFunctions{
+		marshalPayload: client.Synchronization.marshalEntities,
+		upload:         client.Synchronization.Upload,
+	}
+
+	err := client.Synchronization.chunkUpload("job-1", createFakeData(400), fns)
+	assert.NoError(t, err)
+	assert.Equal(t, 400, uploaded)
+}
+
+// simulatedLinkBytesPerSecond is the bandwidth used by the benchmarks to show
+// the effect of compression on a slow link.
+const simulatedLinkBytesPerSecond = 1 << 20
+
+// simulateLink sleeps for as long as it would take to move n bytes over a slow link.
+func simulateLink(n int) {
+	time.Sleep(time.Duration(n) * time.Second / simulatedLinkBytesPerSecond)
+}
+
+func createFakeEntities(n int) []interface{} {
+	output := make([]interface{}, 0, n)
+	for i := 0; i < n; i++ {
+		output = append(output, map[string]interface{}{
+			"_key":        fmt.Sprintf("arn:aws:ec2:us-east-1:123456789012:instance/i-%017d", i),
+			"_type":       "aws_instance",
+			"_class":      []string{"Host"},
+			"displayName": fmt.Sprintf("web-server-%d", i),
+			"region":      "us-east-1",
+			"state":       "running",
+			"public":      false,
+			"tag.Owner":   "platform-team",
+		})
+	}
+	return output
+}
+
+// uploadRecorder decodes (possibly gzipped) sync uploads and records the bytes on the wire.
+type uploadRecorder struct {
+	wireBytes int64
+	entities  int64
+	throttle  bool
+}
+
+func (u *uploadRecorder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	raw, _ := io.ReadAll(r.Body)
+	atomic.AddInt64(&u.wireBytes, int64(len(raw)))
+	if u.throttle {
+		simulateLink(len(raw))
+	}
+
+	body := io.Reader(bytes.NewReader(raw))
+	if r.Header.Get("Content-Encoding") == "gzip" {
+		zr, err := gzip.NewReader(body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		body = zr
+	}
+
+	var payload domain.SyncPayload
+	if err := json.NewDecoder(body).Decode(&payload); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	atomic.AddInt64(&u.entities, int64(len(payload.Entities)))
+
+	_, _ = w.Write([]byte(`{"job":{"id":"job-1"}}`))
+}
+
+func TestGzipUploads(t *testing.T) {
+	recorder := &uploadRecorder{}
+	client := newTestClient(t, recorder)
+	client.gzipUploads = true
+
+	_, err := client.Synchronization.Upload("job-1", domain.SyncPayload{Entities: createFakeEntities(150)})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(150), recorder.entities)
+}
+
+func benchmarkUpload(b *testing.B, gzipUploads bool) {
+	recorder := &uploadRecorder{throttle: true}
+	server := httptest.NewServer(recorder)
+	defer server.Close()
+
+	client, err := NewClient(&Config{APIKey: "a", AccountID: "a", GzipUploads: gzipUploads})
+	if err != nil {
+		b.Fatalf("failed to create client: %v", err)
+	}
+	client.httpBaseURL = server.URL
+
+	payload := domain.SyncPayload{Entities: createFakeEntities(syncChunkSize)}
+	raw, _ := json.Marshal(payload)
+	b.SetBytes(int64(len(raw)))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := client.Synchronization.Upload("job-1", payload); err != nil {
+			b.Fatalf("upload failed: %v", err)
+		}
+	}
+
+	b.ReportMetric(float64(recorder.wireBytes)/float64(b.N), "wire-B/op")
+}
+
+func BenchmarkUploadPlain(b *testing.B) { benchmarkUpload(b, false) }
+
+func BenchmarkUploadGzip(b *testing.B) { benchmarkUpload(b, true) }